@@ -0,0 +1,227 @@
+package pinyin
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Annotation 注音文本的一个片段：汉字及其拼音，或未注音的文本
+type Annotation struct {
+	Text   string   `json:"text"`             // 汉字，或未注音的文本
+	Pinyin []string `json:"pinyin,omitempty"` // 拼音，多音字以 / 分开的各个读音
+}
+
+// 注音的括号：左括号 -> 右括号
+var annotationBrackets = map[rune]rune{'(': ')', '（': '）', '[': ']', '【': '】'}
+
+var (
+	reRuby = regexp.MustCompile(`(?s)<ruby[^>]*>(.*?)</ruby>`)
+	reRp   = regexp.MustCompile(`(?s)<rp[^>]*>.*?</rp>`)
+	reRt   = regexp.MustCompile(`(?s)(.*?)<rt[^>]*>(.*?)</rt>`)
+	reTag  = regexp.MustCompile(`<[^>]*>`)
+)
+
+// ParseAnnotated 解析注音文本，还原为汉字及其拼音.
+// It reverses the Both style, like "中(zhōng) 国(guó)", the bracketed
+// variants, like "中国（zhong1 guo2）" or "行[hang/xing]", and the HTML ruby of
+// Ruby.Render. The pinyin may be in any tone style, with the syllables of a
+// word separated by spaces or apostrophes or, if with tones, not at all, and
+// the polyphones separated by "/". The pinyin in brackets annotates the whole
+// run of Hanzi before them, and if the syllables don't match the Hanzi one by
+// one, the run is returned as a single Annotation. Any other text, including the brackets not
+// holding pinyin, is returned as-is in the Annotations without Pinyin, except
+// that the space following an annotation is dropped, as the separator of
+// Convert, unless a letter or digit follows it.
+func ParseAnnotated(s string) []Annotation {
+	as := annotations{}
+	if !strings.Contains(s, "<ruby") {
+		as.parseBrackets(s)
+		return as
+	}
+	// HTML ruby
+	last := 0
+	for _, m := range reRuby.FindAllStringSubmatchIndex(s, -1) {
+		as.parseBrackets(html.UnescapeString(reTag.ReplaceAllString(s[last:m[0]], "")))
+		inner := reRp.ReplaceAllString(s[m[2]:m[3]], "")
+		for _, rt := range reRt.FindAllStringSubmatch(inner, -1) {
+			base := html.UnescapeString(reTag.ReplaceAllString(rt[1], ""))
+			as.annotate(strings.TrimSpace(base), html.UnescapeString(rt[2]))
+		}
+		last = m[1]
+	}
+	as.parseBrackets(html.UnescapeString(reTag.ReplaceAllString(s[last:], "")))
+	return as
+}
+
+// annotations 解析的结果
+type annotations []Annotation
+
+// text 添加未注音的文本
+func (as *annotations) text(s string) {
+	if s == "" {
+		return
+	}
+	if n := len(*as); n > 0 && (*as)[n-1].Pinyin == nil {
+		(*as)[n-1].Text += s
+		return
+	}
+	*as = append(*as, Annotation{Text: s})
+}
+
+// parseBrackets 解析括号注音的文本 s
+func (as *annotations) parseBrackets(s string) {
+	pending := "" // 尚未处理的文本
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		closing, ok := annotationBrackets[r]
+		j := strings.IndexRune(s[i+size:], closing)
+		if !ok || j < 0 {
+			pending += s[i : i+size]
+			i += size
+			continue
+		}
+		content := s[i+size : i+size+j]
+		base := strings.TrimRightFunc(pending, unicode.IsSpace)
+		n := hanSuffix(base)
+		groups := pinyinGroups(content)
+		if n == 0 || groups == nil {
+			pending += s[i : i+size]
+			i += size
+			continue
+		}
+
+		// 注音括号前连续的 n 个汉字，音节数不符时整体注音
+		head := len(base)
+		for m := 0; m < n; m++ {
+			_, size := utf8.DecodeLastRuneInString(base[:head])
+			head -= size
+		}
+		as.text(base[:head])
+		as.annotate(base[head:], content)
+		pending = ""
+		i += size + j + utf8.RuneLen(closing)
+
+		// 注音后分隔的空白，除非其后为字母或数字
+		if r, size := utf8.DecodeRuneInString(s[i:]); unicode.IsSpace(r) {
+			next, _ := utf8.DecodeRuneInString(s[i+size:])
+			if i+size == len(s) || unicode.Is(unicode.Han, next) ||
+				!unicode.IsLetter(next) && !unicode.IsDigit(next) {
+				i += size
+			}
+		}
+	}
+	as.text(pending)
+}
+
+// annotate 添加汉字 base 的注音 content
+func (as *annotations) annotate(base, content string) {
+	rs := []rune(base)
+	groups := pinyinGroups(content)
+	if len(groups) == 1 && len(rs) > 1 && !strings.Contains(content, "/") {
+		if split := splitSyllables(groups[0], len(rs)); split != nil {
+			groups = split
+		}
+	}
+	if len(groups) != len(rs) {
+		// 注音与汉字对不上，整体注音
+		*as = append(*as, Annotation{Text: base,
+			Pinyin: []string{strings.Join(groups, " ")}})
+		return
+	}
+	for i, r := range rs {
+		*as = append(*as, Annotation{Text: string(r),
+			Pinyin: strings.Split(groups[i], "/")})
+	}
+}
+
+// hanSuffix 返回 s 末尾连续的汉字的个数
+func hanSuffix(s string) int {
+	n := 0
+	for len(s) > 0 {
+		r, size := utf8.DecodeLastRuneInString(s)
+		if !unicode.Is(unicode.Han, r) {
+			break
+		}
+		s = s[:len(s)-size]
+		n++
+	}
+	return n
+}
+
+// pinyinGroups 把注音 content 按空白或隔音符号拆开，各组为一个字的读音，
+// 多音字的读音以 / 分开；content 不是拼音时返回 nil
+func pinyinGroups(content string) []string {
+	groups := strings.Fields(strings.NewReplacer("'", " ", "’", " ").Replace(content))
+	for _, g := range groups {
+		for _, py := range strings.Split(g, "/") {
+			if _, err := ParseSyllable(py); err == nil {
+				continue
+			}
+			// 连写的拼音须带声调，以免误把英文当作拼音，如 China
+			if strings.IndexFunc(py, isToneRune) < 0 || splitSyllables(py, 0) == nil {
+				return nil
+			}
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	return groups
+}
+
+// isToneRune tells whether r is a tone digit, or a tone-marked letter
+func isToneRune(r rune) bool {
+	return r >= '1' && r <= '5' || r >= utf8.RuneSelf
+}
+
+// 连写的拼音最长的字节数，更长的不予拆分
+const maxJoinedPinyin = 64
+
+// splitSyllables 把连写的拼音 s 拆成 n 个音节 (n 为 0 时不限个数)，
+// 优先取较长的音节；无法拆开，或 s 长于 maxJoinedPinyin 时返回 nil
+func splitSyllables(s string, n int) []string {
+	if len(s) > maxJoinedPinyin {
+		return nil
+	}
+	if n <= 0 {
+		n = -1
+	}
+	failed := map[[2]int]bool{} // 无法拆开的 (位置, 音节数)
+	var split func(i, n int) []string
+	split = func(i, n int) []string {
+		switch {
+		case i == len(s) && n <= 0:
+			return []string{}
+		case i == len(s), n == 0, failed[[2]int{i, n}]:
+			return nil
+		}
+		// 音节最长 6 个字母，加上声调
+		ends := []int{}
+		for j := range s[i:] {
+			if j > 0 && len(ends) < 8 {
+				ends = append(ends, i+j)
+			}
+		}
+		if len(ends) < 8 {
+			ends = append(ends, len(s))
+		}
+		rest := n - 1
+		if n < 0 {
+			rest = n
+		}
+		for k := len(ends) - 1; k >= 0; k-- {
+			if _, err := ParseSyllable(s[i:ends[k]]); err != nil {
+				continue
+			}
+			if tail := split(ends[k], rest); tail != nil {
+				return append([]string{s[i:ends[k]]}, tail...)
+			}
+		}
+		failed[[2]int{i, n}] = true
+		return nil
+	}
+	return split(0, n)
+}
@@ -0,0 +1,138 @@
+package pinyin
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func annotationsJSON(as []Annotation) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(as)
+	return string(bytes.TrimSpace(b.Bytes()))
+}
+
+func TestParseAnnotated(t *testing.T) {
+	testData := []struct {
+		s        string
+		expected string // JSON
+	}{
+		{"中(Zhōng) 国(Guó)",
+			`[{"text":"中","pinyin":["Zhōng"]},{"text":"国","pinyin":["Guó"]}]`},
+		{"我爱 中(zhong1) 国 (guo2) ！ 好(hao3) ok",
+			`[{"text":"我爱 "},{"text":"中","pinyin":["zhong1"]},{"text":"国","pinyin":["guo2"]},{"text":"！ "},{"text":"好","pinyin":["hao3"]},{"text":" ok"}]`},
+		{"很 行(hang/xing)。",
+			`[{"text":"很 "},{"text":"行","pinyin":["hang","xing"]},{"text":"。"}]`},
+		{"很行(hang/xing)。",
+			`[{"text":"很行","pinyin":["hang/xing"]},{"text":"。"}]`},
+		{"我爱 中国（zhōng guó）",
+			`[{"text":"我爱 "},{"text":"中","pinyin":["zhōng"]},{"text":"国","pinyin":["guó"]}]`},
+		{"我爱中国（zhōng guó）",
+			`[{"text":"我爱中国","pinyin":["zhōng guó"]}]`},
+		{"西安[xī'ān]",
+			`[{"text":"西","pinyin":["xī"]},{"text":"安","pinyin":["ān"]}]`},
+		{"去《西安【xī’ān】》",
+			`[{"text":"去《"},{"text":"西","pinyin":["xī"]},{"text":"安","pinyin":["ān"]},{"text":"》"}]`},
+		{"中国(zhōngguó)",
+			`[{"text":"中","pinyin":["zhōng"]},{"text":"国","pinyin":["guó"]}]`},
+		{"中国(zhong1guo2)人",
+			`[{"text":"中","pinyin":["zhong1"]},{"text":"国","pinyin":["guo2"]},{"text":"人"}]`},
+		{"中国(China) (a)",
+			`[{"text":"中国(China) (a)"}]`},
+		{"中国(zhongguo)",
+			`[{"text":"中国(zhongguo)"}]`},
+		{"中国人(zhōng guó)",
+			`[{"text":"中国人","pinyin":["zhōng guó"]}]`},
+		{"中(zhōng guó)",
+			`[{"text":"中","pinyin":["zhōng guó"]}]`},
+		{"", `[]`},
+	}
+	for _, tc := range testData {
+		b := annotationsJSON(ParseAnnotated(tc.s))
+		if b != tc.expected {
+			t.Errorf("'%s' expects %s, got %s", tc.s, tc.expected, b)
+		}
+	}
+}
+
+func TestSplitSyllables(t *testing.T) {
+	testData := []struct {
+		s        string
+		n        int
+		expected string
+	}{
+		{"zhōngguó", 0, "zhōng guó"},
+		{"zhōngguó", 2, "zhōng guó"},
+		{"zhōngguó", 3, "zhōng gu ó"},
+		{"zhōngguó", 9, "<nil>"},
+		{"xīān", 0, "xī ān"},
+		{"xīān", 2, "xī ān"},
+		{"", 0, ""},
+		{"", 1, "<nil>"},
+		// 无法拆开的长串，不致指数级回溯
+		{strings.Repeat("nāna", 12) + "q", 0, "<nil>"},
+		{strings.Repeat("nāna", 12) + "q", 24, "<nil>"},
+		{strings.Repeat("nā", 40), 0, "<nil>"},
+	}
+	for _, tc := range testData {
+		v := "<nil>"
+		if split := splitSyllables(tc.s, tc.n); split != nil {
+			v = strings.Join(split, " ")
+		}
+		if v != tc.expected {
+			t.Errorf("'%s', %d expects '%s', got '%s'", tc.s, tc.n, tc.expected, v)
+		}
+	}
+	if as := ParseAnnotated("中(" + strings.Repeat("nāna", 12) + "q)"); len(as) != 1 || as[0].Pinyin != nil {
+		t.Errorf("Unexpected %+v", as)
+	}
+}
+
+func TestParseAnnotatedRuby(t *testing.T) {
+	testData := []struct {
+		s        string
+		expected string // JSON
+	}{
+		{"<ruby>中国<rp>(</rp><rt>zhōng guó</rt><rp>)</rp></ruby>&lt;" +
+			"<ruby>人<rp>(</rp><rt>rén</rt><rp>)</rp></ruby>&gt;",
+			`[{"text":"中","pinyin":["zhōng"]},{"text":"国","pinyin":["guó"]},{"text":"<"},` +
+				`{"text":"人","pinyin":["rén"]},{"text":">"}]`},
+		{`<ruby class="poly" data-readings="xíng/háng">银行<rt>yín háng/xíng</rt></ruby>&amp;`,
+			`[{"text":"银","pinyin":["yín"]},{"text":"行","pinyin":["háng","xíng"]},{"text":"&"}]`},
+		{"<ruby>中<rt>zhōng</rt>国<rt>guó</rt></ruby>",
+			`[{"text":"中","pinyin":["zhōng"]},{"text":"国","pinyin":["guó"]}]`},
+	}
+	for _, tc := range testData {
+		b := annotationsJSON(ParseAnnotated(tc.s))
+		if b != tc.expected {
+			t.Errorf("'%s' expects %s, got %s", tc.s, tc.expected, b)
+		}
+	}
+
+	// 往返
+	hans := "中国人的〖中国银行〗，很行 ok。"
+	for _, a := range []Pinyin{
+		NewPinyin(Tone3, Both, " ", true, true),
+		NewPinyin(Tone1, Both, "", false, false),
+	} {
+		as := ParseAnnotated(a.Convert(hans))
+		text := ""
+		for _, an := range as {
+			text += an.Text
+			if an.Pinyin == nil && hanSuffix(an.Text) > 0 {
+				t.Errorf("'%s' is not annotated", an.Text)
+			}
+		}
+		if text != hans {
+			t.Errorf("Expected '%s', got '%s'", hans, text)
+		}
+	}
+	as := ParseAnnotated(NewRuby(NewPinyin(Tone3, Normal, " ", true, false)).Render(hans))
+	if b := annotationsJSON(as[len(as)-3:]); b !=
+		`[{"text":"很","pinyin":["hěn"]},{"text":"行","pinyin":["xíng","háng","xìng","hàng","héng"]},{"text":" ok。"}]` {
+		t.Errorf("Unexpected %s", b)
+	}
+}
@@ -33,21 +33,44 @@ var hans500 = strings.Replace(strings.Replace(`
 圆、包、火、住、调、满、县、局、照、参、红、细、引、听、该、铁、价、严、龙、飞
 `, "、", "", -1), "\n", "", -1)
 
-func benchmarkPinyin(b *testing.B, s string, args Args) {
-	b.StopTimer()
-	b.StartTimer()
-
+func benchmarkConvert(b *testing.B, s string, a Pinyin) {
+	b.ReportAllocs()
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		Pinyin(s, args)
+		a.Convert(s)
 	}
 }
 
-func BenchmarkPinyinOne(b *testing.B) {
-	args := NewArgs()
-	benchmarkPinyin(b, "中", args)
+func BenchmarkConvertOne(b *testing.B) {
+	benchmarkConvert(b, "中", NewPinyin(Normal, Normal, " ", false, false))
+}
+
+func BenchmarkConvert500(b *testing.B) {
+	benchmarkConvert(b, hans500, NewPinyin(Normal, Normal, " ", false, false))
+}
+
+func BenchmarkConvert500Tone1(b *testing.B) {
+	benchmarkConvert(b, hans500, NewPinyin(Tone1, Normal, " ", false, false))
+}
+
+func BenchmarkConvert500Finals(b *testing.B) {
+	benchmarkConvert(b, hans500, NewPinyin(Tone2, Finals, " ", false, true))
 }
 
-func BenchmarkPinyin500(b *testing.B) {
-	args := NewArgs()
-	benchmarkPinyin(b, hans500, args)
+func BenchmarkConvert500Polyphone(b *testing.B) {
+	benchmarkConvert(b, hans500, NewPinyin(Tone2, ZeroConsonant, " ", true, false))
+}
+
+func BenchmarkConvert500Tone3(b *testing.B) {
+	benchmarkConvert(b, hans500, NewPinyin(Tone3, Normal, " ", false, false))
+}
+
+func BenchmarkAppendConvert500(b *testing.B) {
+	a := NewPinyin(Normal, Normal, " ", false, false)
+	dst := a.AppendConvert(nil, hans500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = a.AppendConvert(dst[:0], hans500)
+	}
 }
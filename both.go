@@ -0,0 +1,99 @@
+package pinyin
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// BothFormatter 双显风格的输出格式.
+// It is given the Han rune r, the chosen reading (all readings joined
+// with '/' in Polyphone mode) and all the readings of r, shaped according
+// to the Pinyin style, and returns the annotated text.
+type BothFormatter func(r rune, reading string, readings []string) string
+
+// BothData is the data that a BothTemplate is executed with
+type BothData struct {
+	Hanzi    string   // 汉字
+	Pinyin   string   // 选用的读音
+	Readings []string // 全部读音
+}
+
+// BothTemplate 返回按模板 text 输出的双显风格格式.
+// The template is executed with BothData, e.g., "{{.Hanzi}}[{{.Pinyin}}]"
+// gives 中[zhōng], "{{.Pinyin}} {{.Hanzi}}" gives zhōng 中, and
+// `{{.Hanzi}}({{join .Readings "|"}})` gives 中(zhōng|zhòng), with join being
+// strings.Join. The index and slice functions are replaced with ones that
+// don't fail past the end: index gives "", e.g., for the second reading of a
+// rune with only one, and slice, which counts the runes of a string, gives
+// what is left, e.g., `slice .Pinyin 0 1` gives the first letter. The template is checked against a sample polyphone,
+// so that it can't fail for the runes converted.
+func BothTemplate(text string) (BothFormatter, error) {
+	tmpl, err := template.New("both").Funcs(template.FuncMap{
+		"join": strings.Join, "index": bothIndex, "slice": bothSlice,
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	sample := BothData{Hanzi: "中", Pinyin: "zhōng", Readings: []string{"zhōng", "zhòng"}}
+	if err := tmpl.Execute(&bytes.Buffer{}, sample); err != nil {
+		return nil, err
+	}
+	return func(r rune, reading string, readings []string) string {
+		buf := bytes.NewBufferString("")
+		tmpl.Execute(buf, BothData{Hanzi: string(r), Pinyin: reading, Readings: readings})
+		return buf.String()
+	}, nil
+}
+
+// bothIndex 返回第 i 个读音，越界时返回 ""
+func bothIndex(readings []string, i int) string {
+	if i < 0 || i >= len(readings) {
+		return ""
+	}
+	return readings[i]
+}
+
+// bothSlice 返回字符串 (按字符计) 或读音 item 的 [i:j]，越界的位置取 item 的末尾
+func bothSlice(item interface{}, indexes ...int) (interface{}, error) {
+	if len(indexes) > 2 {
+		return nil, fmt.Errorf("slice: too many indexes")
+	}
+	n := 0
+	switch v := item.(type) {
+	case string:
+		n = utf8.RuneCountInString(v)
+	case []string:
+		n = len(v)
+	default:
+		return nil, fmt.Errorf("slice: can't slice %T", item)
+	}
+	i, j := 0, n
+	if len(indexes) > 0 {
+		i = indexes[0]
+	}
+	if len(indexes) > 1 {
+		j = indexes[1]
+	}
+	i, j = clamp(i, 0, n), clamp(j, 0, n)
+	if j < i {
+		j = i
+	}
+	if v, ok := item.(string); ok {
+		return string([]rune(v)[i:j]), nil
+	}
+	return item.([]string)[i:j], nil
+}
+
+// clamp 把 i 限制在 [lo, hi] 之间
+func clamp(i, lo, hi int) int {
+	switch {
+	case i < lo:
+		return lo
+	case i > hi:
+		return hi
+	}
+	return i
+}
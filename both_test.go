@@ -0,0 +1,57 @@
+package pinyin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBothFormat(t *testing.T) {
+	a := NewPinyin(Tone3, Both, " ", false, false)
+	testData := []struct {
+		format string
+		result string
+	}{
+		{"{{.Hanzi}}[{{.Pinyin}}]", "中[zhōng] 国[guó] ，"},
+		{"{{.Pinyin}} {{.Hanzi}}", "zhōng 中 guó 国 ，"},
+		{"{{`{`}}{{.Hanzi}}|{{.Pinyin}}{{`}`}}", "{中|zhōng} {国|guó} ，"},
+		{`{{.Hanzi}}({{join .Readings "|"}})`, "中(zhōng|zhòng) 国(guó) ，"},
+		{"{{.Hanzi}}{{index .Readings 0}}", "中zhōng 国guó ，"},
+		// 国 只有一个读音，越界的读音为空
+		{"{{.Hanzi}}{{index .Readings 1}}", "中zhòng 国 ，"},
+		{"{{index .Readings 2}}{{.Hanzi}}", "中 国 ，"},
+		{"{{.Hanzi}}{{slice .Pinyin 0 1}}", "中z 国g ，"},
+		{`{{.Hanzi}}({{join (slice .Readings 1) ","}})`, "中(zhòng) 国() ，"},
+		{"{{.Hanzi}}{{slice .Pinyin 2 9}}", "中ōng 国ó ，"},
+	}
+	for _, tc := range testData {
+		f, err := BothTemplate(tc.format)
+		if err != nil {
+			t.Errorf("'%s' got error %s", tc.format, err)
+			continue
+		}
+		a.BothFormat = f
+		if v := a.Convert("中国，"); v != tc.result {
+			t.Errorf("'%s' expects '%s', got '%s'", tc.format, tc.result, v)
+		}
+	}
+
+	for _, format := range []string{
+		"{{.Hanzi}",              // 语法错误
+		"{{split .Readings}}",    // 未定义的函数
+		"{{.Hanzi}}({{.Tone}})",  // 没有的字段
+		"{{slice .Hanzi 0 1 2}}", // 参数过多
+		"{{index .Hanzi 0}}",     // 不是读音
+	} {
+		if _, err := BothTemplate(format); err == nil {
+			t.Errorf("'%s' expects an error", format)
+		}
+	}
+
+	a = NewPinyin(Normal, Both, "", false, true)
+	a.BothFormat = func(r rune, reading string, readings []string) string {
+		return string(r) + "<" + reading + ":" + strings.Join(readings, ",") + ">"
+	}
+	if v, result := a.Convert("行"), "行<Xing:Xing,Hang,Heng>"; v != result {
+		t.Errorf(`Expected "%s", got "%s"`, result, v)
+	}
+}
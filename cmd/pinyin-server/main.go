@@ -0,0 +1,229 @@
+////////////////////////////////////////////////////////////////////////////
+// Porgram: pinyin-server
+// Purpose: pinyin conversion over HTTP/JSON
+// Authors: Tong Sun (c) 2017, All rights reserved
+////////////////////////////////////////////////////////////////////////////
+
+// pinyin-server exposes the pinyin conversion over a local HTTP/JSON
+// interface, so that non-Go services can use it. It works fully offline.
+//
+//	POST /convert   {"text": "中国人", "tone": 3, "separator": " "}
+//	POST /batch     {"requests": [{"text": "中国"}, {"text": "银行", "polyphone": true}]}
+//	POST /proofread {"text": "银行", "pinyin": "yín xíng"}
+//	GET  /healthz
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	pinyin "github.com/go-cc/cc-pinyin"
+)
+
+////////////////////////////////////////////////////////////////////////////
+// Constant and data type/structure definitions
+
+// Options are the style options that pinyin.NewPinyin accepts
+type Options struct {
+	Tone        int     `json:"tone"`
+	Truncate    int     `json:"truncate"`
+	Separator   *string `json:"separator"` // default: " "
+	Polyphone   bool    `json:"polyphone"`
+	Capitalized bool    `json:"capitalized"`
+}
+
+// Request is a single conversion request
+type Request struct {
+	Text string `json:"text"`
+	Options
+}
+
+// Response is the result of a single conversion request
+type Response struct {
+	Result string         `json:"result"`
+	Tokens []pinyin.Token `json:"tokens"`
+}
+
+// BatchRequest holds several conversion requests
+type BatchRequest struct {
+	Requests []Request `json:"requests"`
+}
+
+// BatchResponse holds the results of a BatchRequest, in the same order
+type BatchResponse struct {
+	Responses []Response `json:"responses"`
+}
+
+// ProofreadRequest holds the Hanzi text and its pinyin to proofread; if the
+// Pinyin is empty, the Text is annotated, like the Both style output
+type ProofreadRequest struct {
+	Text   string `json:"text"`
+	Pinyin string `json:"pinyin"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// server holds the request limits
+type server struct {
+	maxBytes int64 // max size of a request body
+	maxText  int   // max size of a single text, in bytes
+	maxBatch int   // max number of requests in a batch
+}
+
+////////////////////////////////////////////////////////////////////////////
+// Global variables definitions
+
+var (
+	addr     = flag.String("addr", "localhost:8080", "address to listen on")
+	maxBytes = flag.Int64("max-bytes", 1<<20, "max size of a request body, in bytes")
+	maxText  = flag.Int("max-text", 64<<10, "max size of a single text, in bytes")
+	maxBatch = flag.Int("max-batch", 100, "max number of requests in a batch")
+)
+
+////////////////////////////////////////////////////////////////////////////
+// Main
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n %s [flags]\n\nFlags:\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	s := server{maxBytes: *maxBytes, maxText: *maxText, maxBatch: *maxBatch}
+	log.Printf("pinyin-server %s listening on %s", pinyin.VERSION, *addr)
+	log.Fatal(http.ListenAndServe(*addr, s.handler()))
+}
+
+////////////////////////////////////////////////////////////////////////////
+// Function definitions
+
+func (s server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/convert", s.handleConvert)
+	mux.HandleFunc("/batch", s.handleBatch)
+	mux.HandleFunc("/proofread", s.handleProofread)
+	mux.HandleFunc("/healthz", s.handleHealth)
+	return mux
+}
+
+func (s server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if !s.decode(w, r, &req) {
+		return
+	}
+	resp, err := s.convert(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchRequest
+	if !s.decode(w, r, &req) {
+		return
+	}
+	if len(req.Requests) > s.maxBatch {
+		writeError(w, http.StatusRequestEntityTooLarge,
+			fmt.Errorf("too many requests in batch: %d > %d", len(req.Requests), s.maxBatch))
+		return
+	}
+	resp := BatchResponse{Responses: make([]Response, len(req.Requests))}
+	for i, rq := range req.Requests {
+		var err error
+		if resp.Responses[i], err = s.convert(rq); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("request %d: %s", i, err))
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s server) handleProofread(w http.ResponseWriter, r *http.Request) {
+	var req ProofreadRequest
+	if !s.decode(w, r, &req) {
+		return
+	}
+	if n := len(req.Text) + len(req.Pinyin); n > s.maxText {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("text too long: %d > %d bytes", n, s.maxText))
+		return
+	}
+	a := pinyin.NewPinyin(pinyin.Tone3, pinyin.Normal, " ", false, false)
+	if req.Pinyin == "" {
+		writeJSON(w, http.StatusOK, a.ProofreadAnnotated(req.Text))
+		return
+	}
+	writeJSON(w, http.StatusOK, a.Proofread(req.Text, req.Pinyin))
+}
+
+func (s server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"status": "ok", "version": pinyin.VERSION})
+}
+
+// decode reads the JSON request body into v, writing the error response
+// and returning false on failure
+func (s server) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed,
+			fmt.Errorf("method %s not allowed", r.Method))
+		return false
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		status := http.StatusBadRequest
+		if _, ok := err.(*http.MaxBytesError); ok {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeError(w, status, fmt.Errorf("invalid request: %s", err))
+		return false
+	}
+	return true
+}
+
+// convert carries out a single conversion request
+func (s server) convert(req Request) (Response, error) {
+	if len(req.Text) > s.maxText {
+		return Response{}, fmt.Errorf("text too long: %d > %d bytes", len(req.Text), s.maxText)
+	}
+	o := req.Options
+	opts := []pinyin.Option{
+		pinyin.WithTone(pinyin.Tone(o.Tone)),
+		pinyin.WithTruncate(pinyin.Truncate(o.Truncate)),
+	}
+	if o.Separator != nil {
+		opts = append(opts, pinyin.WithSeparator(*o.Separator))
+	}
+	if o.Polyphone {
+		opts = append(opts, pinyin.WithPolyphone())
+	}
+	if o.Capitalized {
+		opts = append(opts, pinyin.WithCapitalized())
+	}
+
+	a, err := pinyin.New(opts...)
+	if err != nil {
+		return Response{}, err
+	}
+	return Response{Result: a.Convert(req.Text), Tokens: a.Tokens(req.Text)}, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pinyin "github.com/go-cc/cc-pinyin"
+)
+
+func post(h http.Handler, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return w
+}
+
+func TestConvert(t *testing.T) {
+	h := server{maxBytes: 1 << 10, maxText: 64, maxBatch: 2}.handler()
+
+	w := post(h, "/convert", `{"text": "中国a", "tone": 3, "separator": "-"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expects status 200, got %d: %s", w.Code, w.Body)
+	}
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Result != "zhōng-guó-a" {
+		t.Errorf(`Expected "zhōng-guó-a", got "%s"`, resp.Result)
+	}
+	if len(resp.Tokens) != 3 || resp.Tokens[1].Pinyin[0] != "guó" || resp.Tokens[2].Han {
+		t.Errorf("unexpected tokens %+v", resp.Tokens)
+	}
+
+	w = post(h, "/batch", `{"requests": [{"text": "中"}, {"text": "行", "polyphone": true}]}`)
+	var batch BatchResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &batch); err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.Responses) != 2 || batch.Responses[0].Result != "zhong " ||
+		batch.Responses[1].Result != "xing/hang/heng " {
+		t.Errorf("unexpected batch response %s", w.Body)
+	}
+}
+
+func TestProofread(t *testing.T) {
+	h := server{maxBytes: 1 << 10, maxText: 64, maxBatch: 2}.handler()
+	for _, body := range []string{
+		`{"text": "我去银行", "pinyin": "wǒ qù yín xíng"}`,
+		`{"text": "我(wǒ) 去(qù) 银(yín) 行(xíng)"}`,
+	} {
+		w := post(h, "/proofread", body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expects status 200, got %d: %s", w.Code, w.Body)
+		}
+		var rp pinyin.Report
+		if err := json.Unmarshal(w.Body.Bytes(), &rp); err != nil {
+			t.Fatal(err)
+		}
+		if rp.Text != "我去银行" || len(rp.Issues) != 1 ||
+			rp.Issues[0].Kind != pinyin.IssueContext || rp.Issues[0].Expected[0] != "háng" {
+			t.Errorf("unexpected report %s", w.Body)
+		}
+	}
+	if w := post(h, "/proofread", `{"text": "`+strings.Repeat("中", 22)+`"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expects status 400, got %d", w.Code)
+	}
+
+	// 无法拆开的长串
+	h = server{maxBytes: 1 << 20, maxText: 64 << 10, maxBatch: 2}.handler()
+	body := `{"text": "中国", "pinyin": "zhong ` + strings.Repeat("nana", 15<<10) + `q"}`
+	if w := post(h, "/proofread", body); w.Code != http.StatusOK ||
+		!strings.Contains(w.Body.String(), `"kind":"invalid"`) {
+		t.Errorf("expects an invalid syllable, got %d", w.Code)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	h := server{maxBytes: 1 << 10, maxText: 6, maxBatch: 1}.handler()
+	testData := []struct {
+		path, body string
+		status     int
+	}{
+		{"/convert", `{"text": "中国人"}`, http.StatusBadRequest},
+		{"/convert", `{"text": "中", "tone": 5}`, http.StatusBadRequest},
+		{"/convert", `{"text": "中", "truncate": 3}`, http.StatusBadRequest},
+		{"/convert", `{"txt": "中"}`, http.StatusBadRequest},
+		{"/convert", `{"text": "` + strings.Repeat("中", 400) + `"}`, http.StatusRequestEntityTooLarge},
+		{"/batch", `{"requests": [{"text": "中"}, {"text": "国"}]}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range testData {
+		if w := post(h, tc.path, tc.body); w.Code != tc.status {
+			t.Errorf("%s %.40s expects status %d, got %d", tc.path, tc.body, tc.status, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/convert", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /convert expects status 405, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"ok"`) {
+		t.Errorf("GET /healthz got %d: %s", w.Code, w.Body)
+	}
+}
@@ -34,6 +34,8 @@ func Example_output() {
 	fmt.Println(a.Convert(hans))
 
 	// 开启多音字模式
+	a = pinyin.NewPinyin(pinyin.Normal, pinyin.Normal, Separator, true, false)
+	fmt.Println(a.Convert(hans))
 	a = pinyin.NewPinyin(pinyin.Tone1, pinyin.Normal, Separator, true, false)
 	fmt.Println(a.Convert(hans))
 	a = pinyin.NewPinyin(pinyin.Tone3, pinyin.Normal, Separator, true, false)
@@ -48,11 +50,29 @@ func Example_output() {
 	// ming-zhu-：《hong-lou-meng-》〖qing-〗cao-xue-qin- zhu-、gao-zuo- xu-／『ren-min-wen-xue-』chu-ban-she-／1996—9yue-30ri-／59.70【yuan-】，《san-guo-yan-yi-》〖ming-〗luo-guan-zhong-。
 
 	// Output:
-	// zhong guo ren de 〖zhong guo yin xing 〗，hen .xing .。
-	// zhōng guó rén de 〖zhōng guó yín xíng 〗，hěn .xíng .。
-	// zho1ng guo2 re2n de 〖zho1ng guo2 yi2n xi2ng 〗，he3n .xi2ng .。
-	// zhong1 guo2 ren2 de 〖zhong1 guo2 yin2 xing2 〗，hen3 .xing2 .。
-	// zho1ng/zho4ng guo2 ren2 de/di4/di2 〖zho1ng/zho4ng guo2 yin2 xi2ng/ha2ng/xi4ng/ha4ng/he2ng 〗，hen3 .xi2ng/ha2ng/xi4ng/ha4ng/he2ng .。
-	// zhōng/zhòng guó rén de/dì/dí 〖zhōng/zhòng guó yín xíng/háng/xìng/hàng/héng 〗，hěn .xíng/háng/xìng/hàng/héng .。
-	// 中(Zhōng) 国(Guó) 银(Yín) 行(Xíng) 。
+	// zhong guo ren de 〖zhong guo yin hang 〗，hen .xing .。
+	// zhōng guó rén de 〖zhōng guó yín háng 〗，hěn .xíng .。
+	// zho1ng guo2 re2n de 〖zho1ng guo2 yi2n ha2ng 〗，he3n .xi2ng .。
+	// zhong1 guo2 ren2 de 〖zhong1 guo2 yin2 hang2 〗，hen3 .xing2 .。
+	// zhong guo ren de/di 〖zhong guo yin hang/xing/heng 〗，hen .xing/hang/heng .。
+	// zhong1/zhong4 guo2 ren2 de/di2/di4 〖zhong1/zhong4 guo2 yin2 hang2/xing2/xing4/hang4/heng2 〗，hen3 .xing2/hang2/xing4/hang4/heng2 .。
+	// zhōng/zhòng guó rén de/dí/dì 〖zhōng/zhòng guó yín háng/xíng/xìng/hàng/héng 〗，hěn .xíng/háng/xìng/hàng/héng .。
+	// 中(Zhōng) 国(Guó) 银(Yín) 行(Háng) 。
+}
+
+func ExampleNew() {
+	a, err := pinyin.New(pinyin.WithTone(pinyin.Tone3), pinyin.WithPolyphone(),
+		pinyin.WithSeparatorPolicy(pinyin.SeparatorBetween))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(a.Convert("我的银行不行"))
+
+	_, err = pinyin.New(pinyin.WithTone(5))
+	fmt.Println(err)
+
+	// Output:
+	// wǒ de/dí/dì yín háng/xíng/xìng/hàng/héng bù/fǒu/fōu/fū/bú xíng/háng/xìng/hàng/héng
+	// pinyin: invalid tone 5
 }
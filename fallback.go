@@ -0,0 +1,113 @@
+package pinyin
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Fallback 处理 PinyinDict 中没有的汉字，见 Pinyin.Fallback.
+// The Han rune r is found at byte offset i of the text s being converted,
+// i.e., the text passed to the method, even if ReadNumbers or the Normalizer
+// has changed it before the conversion.
+// Fallback returns the readings of r, which are shaped and output like the
+// dictionary readings, or if there are none, the text to output instead of r;
+// returning neither drops r. A non-nil error is returned by ConvertErr and
+// TokensErr, while the other methods keep r as-is then.
+type Fallback func(r rune, s string, i int) (readings []string, text string, err error)
+
+// FallbackKeep 原样输出（同没有 Fallback 时）
+func FallbackKeep(r rune, s string, i int) ([]string, string, error) {
+	return nil, s[i : i+utf8.RuneLen(r)], nil
+}
+
+// FallbackDrop 不输出
+func FallbackDrop(r rune, s string, i int) ([]string, string, error) {
+	return nil, "", nil
+}
+
+// FallbackPlaceholder 返回输出 text 以取代汉字的 Fallback
+func FallbackPlaceholder(text string) Fallback {
+	return func(r rune, s string, i int) ([]string, string, error) {
+		return nil, text, nil
+	}
+}
+
+// FallbackError 返回 *UnknownRuneError
+func FallbackError(r rune, s string, i int) ([]string, string, error) {
+	return nil, "", &UnknownRuneError{Rune: r, Offset: i}
+}
+
+// UnknownRuneError 汉字不在 PinyinDict 中，见 FallbackError
+type UnknownRuneError struct {
+	Rune   rune // 汉字
+	Offset int  // 汉字在文本中的字节位置
+}
+
+func (e *UnknownRuneError) Error() string {
+	return fmt.Sprintf("pinyin: no reading for %q at offset %d", e.Rune, e.Offset)
+}
+
+// missing tells whether r is a Han rune not found in PinyinDict
+func missing(r rune) bool {
+	return unicode.Is(unicode.Han, r) && !isHan(r)
+}
+
+// spanOther 返回 s 开头连续的非汉字的长度，有 Fallback 时不包括 PinyinDict 中没有的汉字
+func (a Pinyin) spanOther(s string) int {
+	for i, r := range s {
+		if isHan(r) || a.Fallback != nil && missing(r) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// originOffset 返回变换后的文本中 PinyinDict 中没有的汉字 r 在原文 orig 中的字节位置，
+// 从 from 起查找：foldWidth 及 Verbalize 不增减这样的汉字，也不改变其次序
+func originOffset(orig string, from int, r rune) int {
+	if i := strings.IndexRune(orig[from:], r); i >= 0 {
+		return from + i
+	}
+	return from
+}
+
+// fallback 用 a.Fallback 处理 s 中位于 i 的汉字 r, 依次把 Token 交给 yield,
+// 用 readings 存放读音，返回 readings 以便重用
+func (a Pinyin) fallback(r rune, s string, i int, readings []string, yield func(Token)) ([]string, error) {
+	text := s[i : i+utf8.RuneLen(r)]
+	pys, replacement, err := a.Fallback(r, s, i)
+	switch {
+	case err != nil:
+		yield(Token{Text: text})
+	case len(pys) > 0:
+		if !a.polyphone {
+			// 同 PinyinDict 中的汉字，只取第一个读音
+			pys = pys[:1]
+		}
+		readings = append(readings[:0], pys...)
+		yield(Token{Text: text, Han: true, Pinyin: a.top(distinct(a.shape(readings)))})
+	case replacement != "":
+		yield(Token{Text: replacement})
+	}
+	return readings, err
+}
+
+// TokensErr 同 Tokens，返回 Fallback 的第一个错误
+func (a Pinyin) TokensErr(s string) ([]Token, error) {
+	tokens, err := a.tokens(s)
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// ConvertErr 同 Convert，返回 Fallback 的第一个错误
+func (a Pinyin) ConvertErr(s string) (string, error) {
+	dst, err := a.appendConvert(nil, s)
+	if err != nil {
+		return "", err
+	}
+	return string(dst), nil
+}
@@ -0,0 +1,126 @@
+package pinyin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFallback(t *testing.T) {
+	hans := "中㐂国a鿰"
+	testData := []struct {
+		fallback Fallback
+		expected string
+	}{
+		{nil, "zhong 㐂guo a鿰"},
+		{FallbackKeep, "zhong 㐂guo a鿰"},
+		{FallbackDrop, "zhong guo a"},
+		{FallbackPlaceholder("?"), "zhong ?guo a?"},
+		{func(r rune, s string, i int) ([]string, string, error) {
+			return []string{"xǐ"}, "", nil
+		}, "zhong xi guo axi "},
+		{func(r rune, s string, i int) ([]string, string, error) {
+			return nil, fmt.Sprintf("[%d]", i), nil
+		}, "zhong [3]guo a[10]"},
+	}
+	for _, tc := range testData {
+		a := NewPinyin(Normal, Normal, " ", false, false)
+		a.Fallback = tc.fallback
+		if v := a.Convert(hans); v != tc.expected {
+			t.Errorf("Expected '%s', got '%s'", tc.expected, v)
+		}
+		if v, err := a.ConvertErr(hans); err != nil || v != tc.expected {
+			t.Errorf("Expected '%s', got '%s' (%v)", tc.expected, v, err)
+		}
+	}
+
+	a, _ := New(WithFallback(FallbackError))
+	if v := a.Convert(hans); v != "zhong 㐂guo a鿰" {
+		t.Errorf("Expected 'zhong 㐂guo a鿰', got '%s'", v)
+	}
+	var e *UnknownRuneError
+	if v, err := a.ConvertErr(hans); v != "" || !errors.As(err, &e) || e.Rune != '㐂' || e.Offset != 3 {
+		t.Errorf("Expected the error for '㐂' at 3, got '%s' (%v)", v, err)
+	}
+	if tokens, err := a.TokensErr(hans); tokens != nil || err == nil {
+		t.Errorf("Expected an error, got %v", tokens)
+	}
+	if tokens, err := a.TokensErr("中国"); len(tokens) != 2 || err != nil {
+		t.Errorf("Expected 2 tokens, got %v (%v)", tokens, err)
+	}
+
+	a.Orthographic = true
+	if _, err := a.ConvertErr(hans); err == nil {
+		t.Errorf("Expected an error in orthography")
+	}
+	a.Fallback = FallbackPlaceholder("?")
+	if v, _ := a.ConvertErr(hans); v != "Zhong ? guo a ?" {
+		t.Errorf("Expected 'Zhong ? guo a ?', got '%s'", v)
+	}
+
+	// 位置为原文中的位置，即使读出了数字或转换了全角字符
+	offset := func(r rune, s string, i int) ([]string, string, error) {
+		return nil, fmt.Sprintf("[%d:%s]", i, s[i:i+len(string(r))]), nil
+	}
+	numbers := NewPinyin(Normal, Normal, " ", false, false)
+	numbers.ReadNumbers = true
+	normalized := numbers
+	normalized.Normalizer = NewNormalizer()
+	for _, tc := range []struct {
+		hans          string
+		a             Pinyin
+		result, ortho string
+	}{
+		{"1996年㐂", numbers, "yi jiu jiu liu nian [7:㐂]", "Yi jiu jiu liu nian [7:㐂]"},
+		{"１９９６年㐂", normalized, "yi jiu jiu liu nian [15:㐂]", "Yi jiu jiu liu nian [15:㐂]"},
+		{"Ａ㐂2㐂", normalized, "A[3:㐂]er [7:㐂]", "A [3:㐂] er [7:㐂]"},
+	} {
+		a := tc.a
+		a.Fallback = offset
+		if v := a.Convert(tc.hans); v != tc.result {
+			t.Errorf("Expected '%s', got '%s'", tc.result, v)
+		}
+		a.Orthographic = true
+		if v := a.Convert(tc.hans); v != tc.ortho {
+			t.Errorf("Expected '%s', got '%s'", tc.ortho, v)
+		}
+	}
+	a.Fallback = FallbackError
+	a.ReadNumbers = true
+	if _, err := a.ConvertErr("1996年㐂"); !errors.As(err, &e) || e.Offset != 7 {
+		t.Errorf("Expected the error at 7, got %v", err)
+	}
+	a.Orthographic = false
+	if _, err := a.ConvertErr("1996年㐂"); !errors.As(err, &e) || e.Offset != 7 {
+		t.Errorf("Expected the error at 7, got %v", err)
+	}
+
+	// 多音字模式下才输出全部读音
+	kai := func(r rune, s string, i int) ([]string, string, error) {
+		return []string{"kǎ", "kāi"}, "", nil
+	}
+	ortho := NewPinyin(Normal, Normal, " ", false, false)
+	ortho.Orthographic = true
+	for _, tc := range []testItem{
+		{"中㐂", NewPinyin(Normal, Normal, " ", false, false), "zhong ka "},
+		{"中㐂", NewPinyin(Tone3, Normal, " ", true, false), "zhōng/zhòng kǎ/kāi "},
+		{"中㐂", ortho, "Zhong ka"},
+	} {
+		a := tc.a
+		a.Fallback = kai
+		if v := a.Convert(tc.hans); v != tc.result {
+			t.Errorf("Expected '%s', got '%s'", tc.result, v)
+		}
+	}
+
+	// 不是音节的读音原样输出
+	for _, truncate := range []int{Normal, FirstLetter, Initials, ZeroConsonant, Finals} {
+		a := NewPinyin(Tone3, truncate, " ", false, false)
+		a.Fallback = func(r rune, s string, i int) ([]string, string, error) {
+			return []string{"j"}, "", nil
+		}
+		if v := a.Convert("㐂"); v != "j " {
+			t.Errorf("Expected 'j ', got '%s'", v)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package pinyin
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// IdentCase 标识符的大小写风格
+type IdentCase int
+
+// -- 标识符的大小写风格 IdentCase
+const (
+	LowerCamel   IdentCase = iota // 小驼峰（默认）。如： yongHuMing
+	UpperCamel                    // 大驼峰。如： YongHuMing
+	SnakeCase                     // 下划线连接。如： yong_hu_ming
+	KebabCase                     // 连字符连接。如： yong-hu-ming
+	ConstantCase                  // 大写，下划线连接。如： YONG_HU_MING
+)
+
+// Ident 配置汉字转标识符，见 Identifier
+type Ident struct {
+	Case   IdentCase // 大小写风格（默认：LowerCamel）
+	ByWord bool      // 以词为单位，而不是以音节为单位。如： yongHuming
+	Valid  bool      // 保证输出合法的 Go 及 C 标识符
+}
+
+// 标识符不能用的 Go 及 C 关键字
+var identKeywords = func() map[string]bool {
+	m := map[string]bool{}
+	for _, k := range strings.Fields(`break case chan const continue default defer
+		else fallthrough for func go goto if import interface map package range
+		return select struct switch type var
+		auto char do double enum extern float inline int long register restrict
+		short signed sizeof static typedef union unsigned void volatile while
+		_Bool _Complex _Imaginary`) {
+		m[k] = true
+	}
+	return m
+}()
+
+// Identifier 汉字转标识符或配置项的键名.
+// Each syllable (or word, if ByWord) of the Han text is a part of the
+// identifier, and so is each run of letters or digits of the other text,
+// split at its camel case humps too, while the rest is dropped. E.g., for
+// "用户ID列表", it returns yongHuIdLieBiao in LowerCamel case. The syllables
+// are spelled with the Pinyin style, except for its case and Hanzi.
+//
+// If Valid, the result is an identifier of both Go and C: only the ASCII
+// letters, digits and underscores are kept (tone marks are dropped, and ü is
+// written v unless spelled yu), KebabCase joins with underscores, and an
+// underscore is prepended to a leading digit, or appended to a keyword.
+func (a Pinyin) Identifier(s string, id Ident) string {
+	st := a.Style
+	st.Parts &^= PartHanzi
+	st.Case = CaseLower
+	if id.Valid {
+		if st.Tone == Tone3 {
+			st.Tone = Normal
+		}
+		if st.Umlaut != UmlautYU {
+			st.Umlaut = UmlautV
+		}
+	}
+
+	words := []string{}
+	for len(s) > 0 {
+		if n := spanHan(s, true); n > 0 {
+			for _, w := range a.words(s[:n]) {
+				word := ""
+				for _, py := range a.wordSyllables(w) {
+					if sy, ok := parseSyllable(py); ok && knownSyllable(sy.plain) {
+						py = st.Render(sy)
+					}
+					if !id.ByWord {
+						words = append(words, py)
+					} else {
+						word += py
+					}
+				}
+				if id.ByWord {
+					words = append(words, word)
+				}
+			}
+			s = s[n:]
+			continue
+		}
+		n := spanHan(s, false)
+		words = append(words, identFragments(s[:n])...)
+		s = s[n:]
+	}
+
+	ident := ""
+	for _, w := range words {
+		w = strings.ToLower(w)
+		if id.Valid {
+			w = strings.Map(func(r rune) rune {
+				if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					return r
+				}
+				return -1
+			}, w)
+		}
+		if w == "" {
+			continue
+		}
+		switch id.Case {
+		case LowerCamel, UpperCamel:
+			if ident != "" || id.Case == UpperCamel {
+				w = title(w)
+			}
+		case ConstantCase:
+			w = strings.ToUpper(w)
+		}
+		if ident != "" {
+			switch {
+			case id.Case == KebabCase && !id.Valid:
+				ident += "-"
+			case id.Case == SnakeCase, id.Case == KebabCase, id.Case == ConstantCase:
+				ident += "_"
+			}
+		}
+		ident += w
+	}
+
+	if id.Valid {
+		switch {
+		case ident == "":
+			ident = "_"
+		case ident[0] >= '0' && ident[0] <= '9':
+			ident = "_" + ident
+		case identKeywords[ident]:
+			ident += "_"
+		}
+	}
+	return ident
+}
+
+// identFragments 把非汉字文本 s 拆成标识符的各个部分：连续的字母或数字，
+// 并在驼峰处拆开。如： "HTTPServer v2" -> HTTP, Server, v2
+func identFragments(s string) []string {
+	fragments := []string{}
+	rs := []rune(s)
+	start := -1
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				fragments = append(fragments, string(rs[start:i]))
+			}
+			start = -1
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		// 驼峰：aB 或 ABc 的 B 处拆开
+		if unicode.IsUpper(r) && (unicode.IsLower(rs[i-1]) ||
+			unicode.IsUpper(rs[i-1]) && i+1 < len(rs) && unicode.IsLower(rs[i+1])) {
+			fragments = append(fragments, string(rs[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		fragments = append(fragments, string(rs[start:]))
+	}
+	return fragments
+}
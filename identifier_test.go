@@ -0,0 +1,52 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestIdentifier(t *testing.T) {
+	testData := []struct {
+		s        string
+		id       Ident
+		expected string
+	}{
+		{"用户名称", Ident{}, "yongHuMingCheng"},
+		{"用户名称", Ident{Case: UpperCamel}, "YongHuMingCheng"},
+		{"用户名称", Ident{Case: SnakeCase}, "yong_hu_ming_cheng"},
+		{"用户名称", Ident{Case: KebabCase}, "yong-hu-ming-cheng"},
+		{"用户名称", Ident{Case: ConstantCase}, "YONG_HU_MING_CHENG"},
+		{"中国人民", Ident{ByWord: true}, "zhongguoRenmin"},
+		{"中国人民", Ident{Case: SnakeCase, ByWord: true}, "zhongguo_renmin"},
+		{"用户ID列表", Ident{}, "yongHuIdLieBiao"},
+		{"HTTPServer端口", Ident{Case: SnakeCase}, "http_server_duan_kou"},
+		{"最大 连接-数 (v2)", Ident{Case: KebabCase}, "zui-da-lian-jie-shu-v2"},
+		{"最大 连接-数 (v2)", Ident{Case: KebabCase, Valid: true}, "zui_da_lian_jie_shu_v2"},
+		{"3D打印", Ident{}, "3dDaYin"},
+		{"3D打印", Ident{Valid: true}, "_3dDaYin"},
+		{"绿色", Ident{}, "lvSe"},
+		{"type", Ident{Valid: true}, "type_"},
+		{"！？", Ident{Valid: true}, "_"},
+		{"！？", Ident{}, ""},
+		{"café价格", Ident{Case: SnakeCase}, "café_jia_ge"},
+		{"café价格", Ident{Case: SnakeCase, Valid: true}, "caf_jia_ge"},
+	}
+	a := NewPinyin(Normal, Normal, "", false, false)
+	for _, tc := range testData {
+		if v := a.Identifier(tc.s, tc.id); v != tc.expected {
+			t.Errorf("'%s' %+v expects '%s', got '%s'", tc.s, tc.id, tc.expected, v)
+		}
+	}
+
+	// 拼音风格
+	a, _ = New(WithStyle(Style{Tone: Tone3, Umlaut: UmlautYU}), WithCapitalized())
+	if v := a.Identifier("绿色", Ident{Case: SnakeCase}); v != "lyù_sè" {
+		t.Errorf("Expected 'lyù_sè', got '%s'", v)
+	}
+	if v := a.Identifier("绿色", Ident{Case: SnakeCase, Valid: true}); v != "lyu_se" {
+		t.Errorf("Expected 'lyu_se', got '%s'", v)
+	}
+	a = NewPinyin(Tone1, FirstLetter, "", false, false)
+	if v := a.Identifier("用户名称", Ident{Case: ConstantCase}); v != "Y_H_M_C" {
+		t.Errorf("Expected 'Y_H_M_C', got '%s'", v)
+	}
+}
@@ -0,0 +1,100 @@
+package pinyin
+
+import (
+	"strings"
+	"unicode"
+)
+
+// -- 姓名格式 NameFormat
+const (
+	NameTitle        NameFormat = iota // 姓、名首字母大写。如： Zhang Sanfeng
+	NameUpperSurname                   // 姓全部大写，名首字母大写。如： ZHANG Sanfeng
+)
+
+// NameFormat 配置姓名的输出格式
+type NameFormat int
+
+// Name 姓名的转换结果，姓、名的各个音节分别连写
+type Name struct {
+	Surname         string // 姓
+	GivenName       string // 名
+	SurnamePinyin   string // 姓的拼音。如： ouyang
+	GivenNamePinyin string // 名的拼音。如： sanfeng
+}
+
+// SplitName 把姓名分为姓和名，优先匹配 SurnameDict 中的复姓.
+// If the name contains white space, the first field is taken as the surname;
+// and a name of two runes is always taken as a single surname plus given name.
+func SplitName(name string) (surname, givenName string) {
+	fields := strings.Fields(name)
+	switch {
+	case len(fields) == 0:
+		return "", ""
+	case len(fields) > 1:
+		return fields[0], strings.Join(fields[1:], "")
+	}
+	rs := []rune(fields[0])
+	if len(rs) > 2 {
+		if _, ok := SurnameDict[string(rs[:2])]; ok {
+			return string(rs[:2]), string(rs[2:])
+		}
+	}
+	return string(rs[:1]), string(rs[1:])
+}
+
+// ConvertName 姓名转拼音.
+// The surname is read with its SurnameDict reading if it has one, and the
+// syllables of the surname and of the given name are each joined together,
+// shaped according to the Pinyin style. E.g., for input like "单雄信",
+// the Format(NameTitle) output is Shan Xiongxin.
+func (a Pinyin) ConvertName(name string) Name {
+	n := Name{}
+	n.Surname, n.GivenName = SplitName(name)
+	if value, ok := SurnameDict[n.Surname]; ok {
+		n.SurnamePinyin = strings.Join(a.shape(strings.Fields(value)), "")
+	} else {
+		n.SurnamePinyin = a.joinName(n.Surname)
+	}
+	n.GivenNamePinyin = a.joinName(n.GivenName)
+	n.SurnamePinyin = strings.ToLower(n.SurnamePinyin)
+	n.GivenNamePinyin = strings.ToLower(n.GivenNamePinyin)
+	return n
+}
+
+// joinName 把 s 逐字转拼音并连写
+func (a Pinyin) joinName(s string) string {
+	py := ""
+	for _, t := range a.Tokens(s) {
+		if t.Han {
+			py += t.Pinyin[0]
+		} else {
+			py += t.Text
+		}
+	}
+	return py
+}
+
+// Format 按格式 f 输出姓名的拼音
+func (n Name) Format(f NameFormat) string {
+	surname := title(n.SurnamePinyin)
+	if f == NameUpperSurname {
+		surname = strings.ToUpper(n.SurnamePinyin)
+	}
+	if n.GivenNamePinyin == "" {
+		return surname
+	}
+	return surname + " " + title(n.GivenNamePinyin)
+}
+
+// String 按 NameTitle 格式输出姓名的拼音
+func (n Name) String() string {
+	return n.Format(NameTitle)
+}
+
+// title 把 s 的首字母大写
+func title(s string) string {
+	for i, r := range s {
+		return string(unicode.ToUpper(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestConvertName(t *testing.T) {
+	a := NewPinyin(Normal, Normal, " ", false, false)
+	testData := []struct {
+		name              string
+		surname, given    string
+		title, upperFirst string
+	}{
+		{"张三丰", "张", "三丰", "Zhang Sanfeng", "ZHANG Sanfeng"},
+		{"单雄信", "单", "雄信", "Shan Xiongxin", "SHAN Xiongxin"},
+		{"曾国藩", "曾", "国藩", "Zeng Guofan", "ZENG Guofan"},
+		{"区家麟", "区", "家麟", "Ou Jialin", "OU Jialin"},
+		{"仇英", "仇", "英", "Qiu Ying", "QIU Ying"},
+		{"解缙", "解", "缙", "Xie Jin", "XIE Jin"},
+		{"欧阳修", "欧阳", "修", "Ouyang Xiu", "OUYANG Xiu"},
+		{"司马相如", "司马", "相如", "Sima Xiangru", "SIMA Xiangru"},
+		{"司马", "司", "马", "Si Ma", "SI Ma"},
+		{"上官 婉儿", "上官", "婉儿", "Shangguan Waner", "SHANGGUAN Waner"},
+		{"王", "王", "", "Wang", "WANG"},
+	}
+	for _, tc := range testData {
+		n := a.ConvertName(tc.name)
+		if n.Surname != tc.surname || n.GivenName != tc.given {
+			t.Errorf("'%s' expects %s+%s, got %s+%s", tc.name, tc.surname, tc.given, n.Surname, n.GivenName)
+		}
+		if v := n.Format(NameTitle); v != tc.title {
+			t.Errorf("'%s' expects '%s', got '%s'", tc.name, tc.title, v)
+		}
+		if v := n.Format(NameUpperSurname); v != tc.upperFirst {
+			t.Errorf("'%s' expects '%s', got '%s'", tc.name, tc.upperFirst, v)
+		}
+	}
+
+	a = NewPinyin(Tone3, Normal, " ", false, true)
+	if v := a.ConvertName("欧阳修").String(); v != "Ōuyáng Xiū" {
+		t.Errorf(`Expected "Ōuyáng Xiū", got "%s"`, v)
+	}
+}
@@ -0,0 +1,162 @@
+package pinyin
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// -- 标点符号前后的空白 PunctKind
+const (
+	PunctNone  PunctKind = iota // 不是标点符号
+	PunctOpen                   // 前括号、前引号等，之前有空格
+	PunctClose                  // 逗号、句号、后括号等，之后有空格
+	PunctJoin                   // 连接号、斜线等，前后都没有空格
+)
+
+// PunctKind 标点符号的种类，决定其前后的空白
+type PunctKind int
+
+// Punctuation 标点符号的映射
+type Punctuation struct {
+	Text string    // 映射后的文本
+	Kind PunctKind // 种类
+}
+
+// DefaultPunctuation 默认的标点符号映射表：中文标点 -> ASCII 标点.
+// It is shared by the orthography and all the converters, so it must not be
+// modified; change the Punctuation of a Normalizer instead.
+var DefaultPunctuation = map[rune]Punctuation{
+	'，': {",", PunctClose},
+	'、': {",", PunctClose},
+	'。': {".", PunctClose},
+	'．': {".", PunctClose},
+	'；': {";", PunctClose},
+	'：': {":", PunctClose},
+	'？': {"?", PunctClose},
+	'！': {"!", PunctClose},
+	'…': {"...", PunctClose},
+	'“': {"\"", PunctOpen},
+	'”': {"\"", PunctClose},
+	'‘': {"'", PunctOpen},
+	'’': {"'", PunctClose},
+	'「': {"\"", PunctOpen},
+	'」': {"\"", PunctClose},
+	'『': {"\"", PunctOpen},
+	'』': {"\"", PunctClose},
+	'《': {"\"", PunctOpen},
+	'》': {"\"", PunctClose},
+	'（': {"(", PunctOpen},
+	'）': {")", PunctClose},
+	'【': {"[", PunctOpen},
+	'】': {"]", PunctClose},
+	'〖': {"[", PunctOpen},
+	'〗': {"]", PunctClose},
+	'〔': {"(", PunctOpen},
+	'〕': {")", PunctClose},
+	'〈': {"\"", PunctOpen},
+	'〉': {"\"", PunctClose},
+	'—': {"-", PunctJoin},
+	'～': {"~", PunctJoin},
+	'／': {"/", PunctJoin},
+	'·': {"-", PunctJoin},
+	',': {",", PunctClose},
+	'.': {".", PunctClose},
+	';': {";", PunctClose},
+	':': {":", PunctClose},
+	'?': {"?", PunctClose},
+	'!': {"!", PunctClose},
+	'(': {"(", PunctOpen},
+	')': {")", PunctClose},
+	'[': {"[", PunctOpen},
+	']': {"]", PunctClose},
+	'-': {"-", PunctJoin},
+	'/': {"/", PunctJoin},
+}
+
+// Normalizer 标点符号与全角字符的规范化.
+// ASCII punctuation inside a Latin word or number, like in 59.70 or e-mail,
+// is left as-is.
+type Normalizer struct {
+	Punctuation map[rune]Punctuation // 标点符号映射表
+	FoldWidth   bool                 // 全角字母、数字、符号及空格转为半角
+}
+
+// NewNormalizer 返回使用 DefaultPunctuation 的副本并转换全角字符的 `Normalizer`
+func NewNormalizer() *Normalizer {
+	punctuation := make(map[rune]Punctuation, len(DefaultPunctuation))
+	for r, p := range DefaultPunctuation {
+		punctuation[r] = p
+	}
+	return &Normalizer{Punctuation: punctuation, FoldWidth: true}
+}
+
+// Normalize 规范化 s 中的标点符号与全角字符
+func (n *Normalizer) Normalize(s string) string {
+	out := ""
+	n.eachToken(n.foldWidth(s), func(t Token) {
+		out += t.Text
+	})
+	return out
+}
+
+// eachToken 把非汉字文本 s 分为标点符号与其它文本的 Token，依次交给 yield
+func (n *Normalizer) eachToken(s string, yield func(Token)) {
+	start := 0
+	for i, r := range s {
+		p, ok := n.Punctuation[r]
+		if !ok || inWord(s, i) {
+			continue
+		}
+		if start < i {
+			yield(Token{Text: n.foldRunes(s[start:i])})
+		}
+		yield(Token{Text: p.Text, Punct: p.Kind})
+		start = i + utf8.RuneLen(r)
+	}
+	if start < len(s) {
+		yield(Token{Text: n.foldRunes(s[start:])})
+	}
+}
+
+// foldWidth 全角字母、数字及空格转为半角
+func (n *Normalizer) foldWidth(s string) string {
+	if !n.FoldWidth {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u3000':
+			return ' '
+		case r >= '０' && r <= '９', r >= 'Ａ' && r <= 'Ｚ', r >= 'ａ' && r <= 'ｚ':
+			return r - 0xFEE0
+		}
+		return r
+	}, s)
+}
+
+// foldRunes 全角符号转为半角
+func (n *Normalizer) foldRunes(s string) string {
+	if !n.FoldWidth {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '！' && r <= '～' {
+			return r - 0xFEE0
+		}
+		return r
+	}, s)
+}
+
+// inWord tells whether the ASCII punctuation at s[i] is inside a Latin word
+// or number, like in 59.70 or e-mail
+func inWord(s string, i int) bool {
+	if s[i] > '~' || i == 0 || i == len(s)-1 {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	next, _ := utf8.DecodeRuneInString(s[i+1:])
+	return prev <= '~' && next <= '~' &&
+		(unicode.IsLetter(prev) || unicode.IsDigit(prev)) &&
+		(unicode.IsLetter(next) || unicode.IsDigit(next))
+}
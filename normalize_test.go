@@ -0,0 +1,57 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	n := NewNormalizer()
+	testData := []struct {
+		s, result string
+	}{
+		{"《红楼梦》，〖清〗", "\"红楼梦\",[清]"},
+		{"ＡＢＣ１２３！", "ABC123!"},
+		{"59.70【元】。", "59.70[元]."},
+		{"全角　空格", "全角 空格"},
+	}
+	for _, tc := range testData {
+		if v := n.Normalize(tc.s); v != tc.result {
+			t.Errorf("'%s' expects '%s', got '%s'", tc.s, tc.result, v)
+		}
+	}
+
+	// 修改副本不影响 DefaultPunctuation
+	n.Punctuation['，'] = Punctuation{"，", PunctClose}
+	if v := n.Normalize("中，国。"); v != "中，国." || DefaultPunctuation['，'].Text != "," {
+		t.Errorf(`Expected "中，国." and DefaultPunctuation unchanged, got "%s"`, v)
+	}
+	if v := NewNormalizer().Normalize("中，国"); v != "中,国" {
+		t.Errorf(`Expected "中,国", got "%s"`, v)
+	}
+
+	n = &Normalizer{Punctuation: map[rune]Punctuation{'，': {",", PunctClose}}}
+	if v := n.Normalize("中，《国》ＡＢ"); v != "中,《国》ＡＢ" {
+		t.Errorf(`Expected "中,《国》ＡＢ", got "%s"`, v)
+	}
+
+	between := NewPinyin(Normal, Normal, " ", false, false)
+	between.SeparatorPolicy = SeparatorBetween
+	between.Normalizer = NewNormalizer()
+	boundary := NewPinyin(Normal, Normal, " ", false, false)
+	boundary.SeparatorPolicy = SeparatorBoundary
+	boundary.Normalizer = NewNormalizer()
+	after := NewPinyin(Normal, Normal, " ", false, false)
+	after.Normalizer = NewNormalizer()
+	testPinyinUpdate(t, []testItem{
+		{"中国，很好。", between, "zhong guo, hen hao."},
+		{"〖中国银行〗，很.行.。", between, "[zhong guo yin hang], hen. xing.."},
+		{"读《红楼梦》（清）", between, "du \"hong lou meng\" (qing)"},
+		{"１９９６—９月３０日", boundary, "1996-9 yue 30 ri"},
+		{"用ＧＯ写，59.70元", boundary, "yong GO xie, 59.70 yuan"},
+		{"中国，很好。", after, "zhong guo, hen hao."},
+		{"读《红楼梦》（清）", after, "du \"hong lou meng\" (qing)"},
+		{"〖中国银行〗，很.行.。", after, "[zhong guo yin hang], hen. xing.."},
+		{"１９９６—９月３０日", after, "1996-9yue 30ri "},
+		{"中国", after, "zhong guo "},
+	})
+}
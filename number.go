@@ -0,0 +1,177 @@
+package pinyin
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// 数字的汉字读法
+var numerals = []string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+
+// 量词：其前的 2 读作两。如： 2个 -> 两个
+var measureWords = "个只本件条张位名次天周岁斤米倍台辆种份双对块层片"
+
+// 数字的各个位：个、十、百、千，以及每四位的单位
+var (
+	digitUnits = []string{"", "十", "百", "千"}
+	groupUnits = []string{"", "万", "亿", "万亿"}
+)
+
+// 匹配各种数字写法的正则表达式，按优先次序
+var reNumber = regexp.MustCompile(
+	`(\d{4})\s*(?:年|[-/.—])\s*(\d{1,2})\s*(?:月|[-/.—])\s*(\d{1,2})([日号]?)` + // 1-4: 日期
+		`|(\d{4})年` + // 5: 年份
+		`|(\d{1,2}):(\d{2})(?::(\d{2}))?` + // 6-8: 时间
+		`|(\d+(?:\.\d+)?)[%％]` + // 9: 百分数
+		`|(0\d{2,3}-\d{7,8}|\d{3,4}-\d{3,4}-\d{4}|1[3-9]\d{9}|0\d{2,})(?:\D|$)` + // 10: 电话号码等
+		`|(\d{1,3}(?:,\d{3})+(?:\.\d+)?)(?:\D|$)` + // 11: 千位分隔的数
+		`|(\d+)\.(\d+)` + // 12-13: 小数
+		`|(\d+)`) // 14: 整数
+
+// Verbalize 把 s 中的阿拉伯数字转为汉字读法.
+// It reads integers and decimals (59.70 -> 五十九点七零), years digit by digit
+// (1996年 -> 一九九六年), dates (1996-9-30 -> 一九九六年九月三十日), times
+// (8:05 -> 八点零五分), percentages (12.5% -> 百分之十二点五), and phone-number
+// style sequences digit by digit, with 1 read as 幺 (010-62345678), but only
+// if the digits end there (13912345678, but 139123456789 is an integer).
+// The thousands separators are dropped (3,000 -> 三千).
+// The digit 2 is read as 两 before 千, a leading 万 or 亿, and the measure
+// words, including 点 of times (2000 -> 两千, 2个 -> 两个, but 第2个 -> 第二个).
+func Verbalize(s string) string {
+	if strings.IndexAny(s, "0123456789") < 0 {
+		return s
+	}
+	out := bytes.NewBufferString("")
+	last := 0
+	for _, m := range reNumber.FindAllStringSubmatchIndex(s, -1) {
+		out.WriteString(s[last:m[0]])
+		last = m[1]
+		group := func(i int) string {
+			if m[2*i] < 0 {
+				return ""
+			}
+			return s[m[2*i]:m[2*i+1]]
+		}
+		switch {
+		case group(1) != "":
+			day := group(4)
+			if day == "" {
+				day = "日"
+			}
+			out.WriteString(readDigits(group(1), false) + "年" +
+				readInteger(group(2)) + "月" + readInteger(group(3)) + day)
+		case group(5) != "":
+			out.WriteString(readDigits(group(5), false) + "年")
+		case group(6) != "":
+			hour := readInteger(group(6))
+			if hour == numerals[2] {
+				hour = "两" // 两点
+			}
+			out.WriteString(hour + "点" + readMinutes(group(7), "分"))
+			if group(8) != "" {
+				out.WriteString(readMinutes(group(8), "秒"))
+			}
+		case group(9) != "":
+			out.WriteString("百分之" + readNumber(group(9)))
+		case group(10) != "":
+			// 其后的字符不属于电话号码，原样保留
+			out.WriteString(readDigits(group(10), true))
+			last = m[21]
+		case group(11) != "":
+			out.WriteString(readNumber(strings.Replace(group(11), ",", "", -1)))
+			last = m[23]
+		case group(12) != "":
+			out.WriteString(readNumber(group(12) + "." + group(13)))
+		default:
+			n := readNumber(group(14))
+			if r, _ := utf8.DecodeRuneInString(s[m[1]:]); n == numerals[2] &&
+				strings.ContainsRune(measureWords, r) && !strings.HasSuffix(s[:m[0]], "第") {
+				n = "两" // 两个，但第二个
+			}
+			out.WriteString(n)
+		}
+	}
+	out.WriteString(s[last:])
+	return out.String()
+}
+
+// readNumber 读整数或小数。如： 59.70 -> 五十九点七零
+func readNumber(d string) string {
+	if i := strings.Index(d, "."); i >= 0 {
+		return readInteger(d[:i]) + "点" + readDigits(d[i+1:], false)
+	}
+	return readInteger(d)
+}
+
+// readInteger 读整数。如： 10086 -> 一万零八十六
+func readInteger(d string) string {
+	d = strings.TrimLeft(d, "0")
+	if d == "" {
+		return numerals[0]
+	}
+	if len(d) > 4*len(groupUnits) {
+		return readDigits(d, false)
+	}
+
+	s, zero := "", false // zero: 有待读出的零
+	for k := (len(d)+3)/4 - 1; k >= 0; k-- {
+		group := d[:len(d)-4*k]
+		if len(group) > 4 {
+			group = group[len(group)-4:]
+		}
+		group = strings.Repeat("0", 4-len(group)) + group
+		if group == "0000" {
+			zero = zero || s != ""
+			continue
+		}
+		for i, c := range group {
+			if c == '0' {
+				zero = zero || s != ""
+				continue
+			}
+			if zero {
+				s += numerals[0]
+				zero = false
+			}
+			numeral := numerals[c-'0']
+			if c == '2' && (i == 0 || s == "" && k > 0 && group == "0002") {
+				numeral = "两" // 两千，以及开头的两万、两亿
+			}
+			s += numeral + digitUnits[3-i]
+		}
+		s += groupUnits[k]
+	}
+	if strings.HasPrefix(s, "一十") {
+		s = strings.TrimPrefix(s, "一") // 一十二 -> 十二
+	}
+	return s
+}
+
+// readMinutes 读分、秒，00 不读出。如： 05 -> 零五分
+func readMinutes(d, unit string) string {
+	switch {
+	case d == "00":
+		return ""
+	case d[0] == '0':
+		return numerals[0] + numerals[d[1]-'0'] + unit
+	}
+	return readInteger(d) + unit
+}
+
+// readDigits 逐位读数字，yao 为真时 1 读作幺，其它字符原样保留
+func readDigits(d string, yao bool) string {
+	s := ""
+	for _, c := range d {
+		switch {
+		case c == '1' && yao:
+			s += "幺"
+		case c >= '0' && c <= '9':
+			s += numerals[c-'0']
+		default:
+			s += string(c)
+		}
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestVerbalize(t *testing.T) {
+	testData := []struct {
+		s, result string
+	}{
+		{"0", "零"},
+		{"7", "七"},
+		{"12", "十二"},
+		{"20", "二十"},
+		{"59", "五十九"},
+		{"105", "一百零五"},
+		{"1010", "一千零一十"},
+		{"1996", "一千九百九十六"},
+		{"10086", "一万零八十六"},
+		{"100000", "十万"},
+		{"100100", "十万零一百"},
+		{"10000001", "一千万零一"},
+		{"300000000", "三亿"},
+		{"59.70元", "五十九点七零元"},
+		{"1996年", "一九九六年"},
+		{"1996—9月30日", "一九九六年九月三十日"},
+		{"2017-05-03", "二零一七年五月三日"},
+		{"8:00", "八点"},
+		{"8:05", "八点零五分"},
+		{"23:30:15", "二十三点三十分十五秒"},
+		{"12.5%", "百分之十二点五"},
+		{"010-62345678", "零幺零-六二三四五六七八"},
+		{"13912345678", "幺三九幺二三四五六七八"},
+		{"400-820-8820", "四零零-八二零-八八二零"},
+		{"1996-2000", "一千九百九十六-两千"},
+		{"2", "二"},
+		{"2000元", "两千元"},
+		{"2222", "两千二百二十二"},
+		{"20000", "两万"},
+		{"120000", "十二万"},
+		{"1020000", "一百零二万"},
+		{"22000000", "两千二百万"},
+		{"200000000", "两亿"},
+		{"2个", "两个"},
+		{"12个", "十二个"},
+		{"第2个", "第二个"},
+		{"2月", "二月"},
+		{"2:30", "两点三十分"},
+		{"12:00", "十二点"},
+		{"第3章", "第三章"},
+		{"10000000000", "一百亿"},
+		{"139123456789", "一千三百九十一亿两千三百四十五万六千七百八十九"},
+		{"123456789012345678901", "一二三四五六七八九零一二三四五六七八九零一"},
+		{"13912345678。", "幺三九幺二三四五六七八。"},
+		{"电话13912345678或010-62345678", "电话幺三九幺二三四五六七八或零幺零-六二三四五六七八"},
+		{"3,000", "三千"},
+		{"3,000人", "三千人"},
+		{"1,234,567.5元", "一百二十三万四千五百六十七点五元"},
+		{"1,2", "一,二"},
+		{"1,2345", "一,两千三百四十五"},
+	}
+	for _, tc := range testData {
+		if v := Verbalize(tc.s); v != tc.result {
+			t.Errorf("'%s' expects '%s', got '%s'", tc.s, tc.result, v)
+		}
+	}
+
+	a := NewPinyin(Tone3, Normal, " ", false, false)
+	a.ReadNumbers = true
+	a.SeparatorPolicy = SeparatorBetween
+	testPinyinUpdate(t, []testItem{
+		{"59.70元", a, "wǔ shí jiǔ diǎn qī líng yuán"},
+		{"1996—9月30日", a, "yī jiǔ jiǔ liù nián jiǔ yuè sān shí rì"},
+		{"2000元买2本书", a, "liǎng qiān yuán mǎi liǎng běn shū"},
+	})
+}
@@ -0,0 +1,187 @@
+package pinyin
+
+import (
+	"fmt"
+)
+
+// Tone 声调风格：Normal, Tone1, Tone2 或 Tone3
+type Tone int
+
+// Valid tells whether t is one of the defined tone styles
+func (t Tone) Valid() bool {
+	return t >= Normal && t <= Tone3
+}
+
+// Truncate 部分返回：Normal, FirstLetter, Initials, ZeroConsonant, Finals 或 Both
+type Truncate int
+
+// Valid tells whether t is one of the defined truncate styles
+func (t Truncate) Valid() bool {
+	switch t {
+	case Normal, FirstLetter, Initials, ZeroConsonant, Finals, Both:
+		return true
+	}
+	return false
+}
+
+// Option 配置 `Pinyin` 的选项，见 New
+type Option func(*Pinyin) error
+
+// New 返回按选项 opts 配置的 `Pinyin`.
+// Without any options, it is the same as
+// NewPinyin(Normal, Normal, " ", false, false). E.g.,
+//
+//	a, err := New(WithTone(Tone3), WithPolyphone())
+//
+// It returns the error of the first invalid option.
+func New(opts ...Option) (Pinyin, error) {
+	a := Pinyin{Style: NewStyle(Normal, Normal),
+		Separator: " ",
+		Segmenter: DefaultSegmenter,
+	}
+	for _, opt := range opts {
+		if err := opt(&a); err != nil {
+			return Pinyin{}, err
+		}
+	}
+	return a.init(), nil
+}
+
+// WithTone 设置声调风格（默认：Normal）
+func WithTone(t Tone) Option {
+	return func(a *Pinyin) error {
+		if !t.Valid() {
+			return fmt.Errorf("pinyin: invalid tone %d", t)
+		}
+		a.Tone = t
+		return nil
+	}
+}
+
+// WithTruncate 设置部分返回（默认：Normal），即输出的部分，见 NewStyle
+func WithTruncate(t Truncate) Option {
+	return func(a *Pinyin) error {
+		if !t.Valid() {
+			return fmt.Errorf("pinyin: invalid truncate %d", t)
+		}
+		st := NewStyle(Normal, int(t))
+		a.Parts, a.NormalizeYW = st.Parts, st.NormalizeYW
+		return nil
+	}
+}
+
+// WithStyle 设置拼音风格，取代 WithTone, WithTruncate 及 WithCapitalized
+func WithStyle(st Style) Option {
+	return func(a *Pinyin) error {
+		if err := st.Valid(); err != nil {
+			return err
+		}
+		a.Style = st
+		return nil
+	}
+}
+
+// WithSeparator 设置分隔符（默认：" "）
+func WithSeparator(separator string) Option {
+	return func(a *Pinyin) error {
+		a.Separator = separator
+		return nil
+	}
+}
+
+// WithSeparatorPolicy 设置分隔符的位置（默认：SeparatorAfter）
+func WithSeparatorPolicy(p SeparatorPolicy) Option {
+	return func(a *Pinyin) error {
+		if p < SeparatorAfter || p > SeparatorBoundary {
+			return fmt.Errorf("pinyin: invalid separator policy %d", p)
+		}
+		a.SeparatorPolicy = p
+		return nil
+	}
+}
+
+// WithPolyphone 启用多音字模式
+func WithPolyphone() Option {
+	return func(a *Pinyin) error {
+		a.polyphone = true
+		return nil
+	}
+}
+
+// WithPolyphoneTop 多音字模式下最多输出 n 个读音
+func WithPolyphoneTop(n int) Option {
+	return func(a *Pinyin) error {
+		if n < 0 {
+			return fmt.Errorf("pinyin: invalid polyphone top %d", n)
+		}
+		a.PolyphoneTop = n
+		return nil
+	}
+}
+
+// WithPolyphoneMinProb 多音字模式下只输出概率不低于 p 的读音
+func WithPolyphoneMinProb(p float64) Option {
+	return func(a *Pinyin) error {
+		if p < 0 || p > 1 {
+			return fmt.Errorf("pinyin: invalid polyphone probability %g", p)
+		}
+		a.PolyphoneMinProb = p
+		return nil
+	}
+}
+
+// WithCapitalized 首字母大写
+func WithCapitalized() Option {
+	return func(a *Pinyin) error {
+		a.Case = CaseTitle
+		return nil
+	}
+}
+
+// WithBothFormat 设置双显风格的输出格式
+func WithBothFormat(f BothFormatter) Option {
+	return func(a *Pinyin) error {
+		a.BothFormat = f
+		return nil
+	}
+}
+
+// WithOrthography 按正词法输出
+func WithOrthography() Option {
+	return func(a *Pinyin) error {
+		a.Orthographic = true
+		return nil
+	}
+}
+
+// WithSegmenter 设置分词器，为 nil 则逐字转换（默认：DefaultSegmenter）
+func WithSegmenter(sg *Segmenter) Option {
+	return func(a *Pinyin) error {
+		a.Segmenter = sg
+		return nil
+	}
+}
+
+// WithNumbers 读出阿拉伯数字
+func WithNumbers() Option {
+	return func(a *Pinyin) error {
+		a.ReadNumbers = true
+		return nil
+	}
+}
+
+// WithNormalizer 设置标点符号与全角字符的规范化
+func WithNormalizer(n *Normalizer) Option {
+	return func(a *Pinyin) error {
+		a.Normalizer = n
+		return nil
+	}
+}
+
+// WithFallback 设置 PinyinDict 中没有的汉字的处理方法，见 Fallback
+func WithFallback(f Fallback) Option {
+	return func(a *Pinyin) error {
+		a.Fallback = f
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	testData := []struct {
+		opts   []Option
+		result string
+	}{
+		{nil, "zhong guo ren de "},
+		{[]Option{WithTone(Tone3), WithPolyphone()}, "zhōng/zhòng guó rén de/dí/dì "},
+		{[]Option{WithTone(Tone1), WithTruncate(Finals), WithSeparator("-")}, "ong1-uo2-en2-e-"},
+		{[]Option{WithCapitalized(), WithSeparatorPolicy(SeparatorBetween)}, "Zhong Guo Ren De"},
+		{[]Option{WithTone(Tone3), WithTruncate(Both), WithSeparator("")}, "中(zhōng)国(guó)人(rén)的(de)"},
+		{[]Option{WithPolyphone(), WithPolyphoneTop(1), WithSegmenter(nil)}, "zhong guo ren de "},
+		{[]Option{WithOrthography()}, "Zhongguoren de"},
+	}
+	for _, tc := range testData {
+		a, err := New(tc.opts...)
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+			continue
+		}
+		if v := a.Convert("中国人的"); v != tc.result {
+			t.Errorf(`Expected "%s", got "%s"`, tc.result, v)
+		}
+	}
+
+	for _, opt := range []Option{WithTone(4), WithTone(-1), WithTruncate(3),
+		WithTruncate(Tone3), WithSeparatorPolicy(3), WithPolyphoneTop(-1),
+		WithPolyphoneMinProb(1.5)} {
+		if _, err := New(opt); err == nil {
+			t.Errorf("expects an invalid option error")
+		}
+	}
+
+	a, _ := New(WithTone(Tone2), WithTruncate(ZeroConsonant))
+	b := NewPinyin(Tone2, ZeroConsonant, " ", false, false)
+	if v, w := a.Convert("侵略"), b.Convert("侵略"); v != w {
+		t.Errorf(`Expected "%s", got "%s"`, w, v)
+	}
+}
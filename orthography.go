@@ -0,0 +1,158 @@
+package pinyin
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// orthoItem 正词法的片段：词 (kind 为 PunctNone)，或标点符号
+type orthoItem struct {
+	text string
+	kind PunctKind
+}
+
+// orthography 按《汉语拼音正词法基本规则》(GB/T 16159) 转拼音.
+// The syllables of a word are joined, with an apostrophe before the a/o/e
+// syllables inside the word; words are separated with a space, proper nouns
+// and sentence starts are capitalized, and the Chinese punctuation is mapped
+// to its Latin equivalent. It returns the first error of the Fallback, if any.
+func (a Pinyin) orthography(s string) (string, error) {
+	punctuation := DefaultPunctuation
+	orig, at := s, 0 // 原文及其中已处理的字节数，用于 Fallback
+	if a.Normalizer != nil {
+		s = a.Normalizer.foldWidth(s)
+		punctuation = a.Normalizer.Punctuation
+	}
+	if a.ReadNumbers {
+		s = Verbalize(s)
+	}
+	// 音节按小写处理，大小写按词处理
+	lower := a
+	if a.Case != CaseLower {
+		lower.Case = CaseLower
+		lower = lower.init()
+	}
+	items := []orthoItem{}
+	han, other := -1, -1 // start of the pending Han and non-Han text
+	flush := func(i int) {
+		if han >= 0 {
+			for _, w := range a.words(s[han:i]) {
+				items = append(items, orthoItem{a.orthoCase(lower.orthoWord(w)), PunctNone})
+			}
+		}
+		if other >= 0 {
+			items = append(items, orthoItem{s[other:i], PunctNone})
+		}
+		han, other = -1, -1
+	}
+	var err error
+	for i, r := range s {
+		if a.Fallback != nil && missing(r) {
+			// PinyinDict 中没有的汉字
+			j := i
+			if s != orig {
+				j = originOffset(orig, at, r)
+			}
+			at = j + utf8.RuneLen(r)
+			readings, text, e := a.Fallback(r, orig, j)
+			switch {
+			case e != nil:
+				if err == nil {
+					err = e
+				}
+				text = orig[j:at]
+			case len(readings) > 0:
+				text = a.orthoCase(lower.shapeOne(readings[0]))
+			}
+			flush(i)
+			if text != "" {
+				items = append(items, orthoItem{text, PunctNone})
+			}
+			continue
+		}
+		if isHan(r) {
+			if han < 0 {
+				flush(i)
+				han = i
+			}
+			continue
+		}
+		if p, ok := punctuation[r]; ok && !inWord(s, i) {
+			flush(i)
+			items = append(items, orthoItem{p.Text, p.Kind})
+		} else if unicode.IsSpace(r) {
+			flush(i)
+		} else if other < 0 {
+			flush(i)
+			other = i
+		}
+	}
+	flush(len(s))
+
+	out := ""
+	sentence := true // at the start of a sentence
+	for i, it := range items {
+		if i > 0 && items[i-1].kind != PunctOpen && items[i-1].kind != PunctJoin &&
+			it.kind != PunctClose && it.kind != PunctJoin {
+			out += " "
+		}
+		switch {
+		case it.kind == PunctNone && sentence:
+			out += title(it.text)
+			sentence = false
+		case it.kind == PunctNone:
+			out += it.text
+		default:
+			out += it.text
+			sentence = sentence || strings.ContainsAny(it.text, ".?!")
+		}
+	}
+	return out, err
+}
+
+// orthoWord 把词 w 的各个音节连写，a/o/e 开头的音节前加隔音符号
+func (a Pinyin) orthoWord(w string) string {
+	syllables := a.wordSyllables(w)
+
+	py := ""
+	for i, syllable := range syllables {
+		if i > 0 && strings.ContainsRune("aāáǎàoōóǒòeēéěè", firstRune(syllable)) {
+			py += "'"
+		}
+		py += a.shapeOne(syllable)
+	}
+	if ProperNouns[w] {
+		py = title(py)
+	}
+	return py
+}
+
+// orthoCase 按 a 的大小写输出正词法的词 py：CaseTitle 时各词首字母大写，
+// CaseUpper 时全部大写
+func (a Pinyin) orthoCase(py string) string {
+	switch a.Case {
+	case CaseTitle:
+		return title(py)
+	case CaseUpper:
+		return strings.ToUpper(py)
+	}
+	return py
+}
+
+// wordSyllables 返回词 w 的各个音节（声调在韵母上）：词语读音，或各个字的第一个读音
+func (a Pinyin) wordSyllables(w string) []string {
+	syllables := a.phraseReading(w)
+	if syllables == nil {
+		for _, r := range w {
+			syllables = append(syllables, firstReading(r))
+		}
+	}
+	return syllables
+}
+
+// firstRune 返回 s 的首个字符
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
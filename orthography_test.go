@@ -0,0 +1,54 @@
+package pinyin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOrthography(t *testing.T) {
+	a := NewPinyin(Tone3, Normal, " ", false, false)
+	a.Orthographic = true
+	n := NewPinyin(Normal, Normal, " ", false, false)
+	n.Orthographic = true
+	testData := []testItem{
+		{"中国人", a, "Zhōngguórén"},
+		{"西安", a, "Xī'ān"},
+		{"皮袄", a, "Pí'ǎo"},
+		{"我们去天安门。", a, "Wǒmen qù Tiān'ānmén."},
+		{"你好，世界！我喜欢音乐。", a, "Nǐhǎo, shìjiè! Wǒ xǐhuan yīnyuè."},
+		{"《红楼梦》很好", a, "\"Hónglóumèng\" hěn hǎo"},
+		{"59.70元", a, "59.70 yuán"},
+		{"1996—9月30日", n, "1996-9 yue 30 ri"},
+		{"用Go写（中文）", n, "Yong Go xie (zhongwen)"},
+		{"〖中国银行〗，很.行.。", n, "[Zhongguo yinhang], hen. Xing.."},
+	}
+	testPinyinUpdate(t, testData)
+
+	// 大小写按词处理
+	title := NewPinyin(Tone3, Normal, " ", false, true)
+	title.Orthographic = true
+	upper := a
+	upper.Case = CaseUpper
+	testPinyinUpdate(t, []testItem{
+		{"中国人去西安", title, "Zhōngguórén Qù Xī'ān"},
+		{"中国人去西安", upper, "ZHŌNGGUÓRÉN QÙ XĪ'ĀN"},
+	})
+}
+
+func TestPhraseDict(t *testing.T) {
+	for w, value := range PhraseDict {
+		syllables := strings.Fields(value)
+		rs := []rune(w)
+		if len(syllables) != len(rs) {
+			t.Errorf("'%s' has %d syllables: %s", w, len(syllables), value)
+			continue
+		}
+		a := NewPinyin(Normal, Normal, "", true, false)
+		for i, r := range rs {
+			py := a.shapeOne(syllables[i])
+			if !strings.Contains(","+strings.Join(a.readings(r), ",")+",", ","+py+",") {
+				t.Errorf("'%s': '%s' is not a reading of %c", w, syllables[i], r)
+			}
+		}
+	}
+}
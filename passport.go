@@ -0,0 +1,30 @@
+package pinyin
+
+import (
+	"strings"
+)
+
+// PassportName 护照式的姓名拼音：全部大写，不带声调，ü 写作 YU。如： LYU XIAOMING
+type PassportName struct {
+	Surname   string `json:"surname"`    // 姓。如： OUYANG
+	GivenName string `json:"given_name"` // 名，各音节连写。如： XIAOMING
+}
+
+// passportPinyin 护照姓名所用的拼音风格
+var passportPinyin, _ = New(WithStyle(Style{Umlaut: UmlautYU}))
+
+// Passport 姓名转护照式拼音.
+// The name is split and read as in ConvertName, then the tone marks are
+// dropped, ü is written as YU (吕 -> LYU, 女 -> NYU), and all is uppercased.
+func Passport(name string) PassportName {
+	n := passportPinyin.ConvertName(name)
+	return PassportName{
+		Surname:   strings.ToUpper(n.SurnamePinyin),
+		GivenName: strings.ToUpper(n.GivenNamePinyin),
+	}
+}
+
+// String 输出护照式的姓名拼音，姓在前，与名以空格分开
+func (p PassportName) String() string {
+	return strings.TrimSpace(p.Surname + " " + p.GivenName)
+}
@@ -0,0 +1,24 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestPassport(t *testing.T) {
+	testData := []struct {
+		name, surname, given, result string
+	}{
+		{"吕小明", "LYU", "XIAOMING", "LYU XIAOMING"},
+		{"女娲", "NYU", "WA", "NYU WA"},
+		{"徐略", "XU", "LYUE", "XU LYUE"},
+		{"欧阳娜娜", "OUYANG", "NANA", "OUYANG NANA"},
+		{"曾志伟", "ZENG", "ZHIWEI", "ZENG ZHIWEI"},
+		{"王", "WANG", "", "WANG"},
+	}
+	for _, tc := range testData {
+		p := Passport(tc.name)
+		if p.Surname != tc.surname || p.GivenName != tc.given || p.String() != tc.result {
+			t.Errorf("'%s' expects %s/%s, got %s/%s", tc.name, tc.surname, tc.given, p.Surname, p.GivenName)
+		}
+	}
+}
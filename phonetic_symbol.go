@@ -23,6 +23,7 @@ var phoneticSymbol = map[string]string{
 	"ǔ": "u3",
 	"ù": "u4",
 	"ü": "v",
+	"ǖ": "v1",
 	"ǘ": "v2",
 	"ǚ": "v3",
 	"ǜ": "v4",
@@ -0,0 +1,266 @@
+package pinyin
+
+// PhraseDict is phrase data map, 词语 -> 拼音 (以空格分隔各个音节)
+var PhraseDict = map[string]string{
+	"爱好":   "ài hào",
+	"爸爸":   "bà ba",
+	"北京":   "běi jīng",
+	"蚌埠":   "bèng bù",
+	"便宜":   "pián yi",
+	"不会":   "bù huì",
+	"不是":   "bù shì",
+	"不行":   "bù xíng",
+	"不要":   "bù yào",
+	"参加":   "cān jiā",
+	"曾经":   "céng jīng",
+	"差不多":  "chà bu duō",
+	"长城":   "cháng chéng",
+	"长度":   "cháng dù",
+	"长江":   "cháng jiāng",
+	"长沙":   "cháng shā",
+	"车站":   "chē zhàn",
+	"成为":   "chéng wéi",
+	"成长":   "chéng zhǎng",
+	"重庆":   "chóng qìng",
+	"重新":   "chóng xīn",
+	"出版社":  "chū bǎn shè",
+	"出差":   "chū chāi",
+	"处理":   "chǔ lǐ",
+	"传说":   "chuán shuō",
+	"词典":   "cí diǎn",
+	"答案":   "dá àn",
+	"大夫":   "dài fu",
+	"大家":   "dà jiā",
+	"大学":   "dà xué",
+	"单位":   "dān wèi",
+	"但是":   "dàn shì",
+	"档案":   "dàng àn",
+	"得到":   "dé dào",
+	"德国":   "dé guó",
+	"的确":   "dí què",
+	"地方":   "dì fang",
+	"弟弟":   "dì di",
+	"电话":   "diàn huà",
+	"电脑":   "diàn nǎo",
+	"电视":   "diàn shì",
+	"电影":   "diàn yǐng",
+	"调查":   "diào chá",
+	"东西":   "dōng xi",
+	"对不起":  "duì bu qǐ",
+	"恩爱":   "ēn ài",
+	"儿子":   "ér zi",
+	"发现":   "fā xiàn",
+	"发展":   "fā zhǎn",
+	"法国":   "fǎ guó",
+	"饭店":   "fàn diàn",
+	"方案":   "fāng àn",
+	"方便":   "fāng biàn",
+	"房子":   "fáng zi",
+	"飞机":   "fēi jī",
+	"干净":   "gān jìng",
+	"干部":   "gàn bù",
+	"高兴":   "gāo xìng",
+	"哥哥":   "gē ge",
+	"工作":   "gōng zuò",
+	"公安":   "gōng ān",
+	"公司":   "gōng sī",
+	"广州":   "guǎng zhōu",
+	"国家":   "guó jiā",
+	"还是":   "hái shì",
+	"还有":   "hái yǒu",
+	"孩子":   "hái zi",
+	"海鸥":   "hǎi ōu",
+	"汉语":   "hàn yǔ",
+	"汉字":   "hàn zì",
+	"行业":   "háng yè",
+	"好处":   "hǎo chù",
+	"红楼梦":  "hóng lóu mèng",
+	"欢迎":   "huān yíng",
+	"还原":   "huán yuán",
+	"黄河":   "huáng hé",
+	"火车":   "huǒ chē",
+	"几乎":   "jī hū",
+	"机场":   "jī chǎng",
+	"几个":   "jǐ gè",
+	"记得":   "jì de",
+	"技术":   "jì shù",
+	"教育":   "jiào yù",
+	"姐姐":   "jiě jie",
+	"今年":   "jīn nián",
+	"今天":   "jīn tiān",
+	"经济":   "jīng jì",
+	"角色":   "jué sè",
+	"觉得":   "jué de",
+	"可爱":   "kě ài",
+	"可以":   "kě yǐ",
+	"科学":   "kē xué",
+	"空气":   "kōng qì",
+	"快乐":   "kuài lè",
+	"会计":   "kuài jì",
+	"老师":   "lǎo shī",
+	"乐观":   "lè guān",
+	"理发":   "lǐ fà",
+	"历史":   "lì shǐ",
+	"了解":   "liǎo jiě",
+	"六安":   "lù ān",
+	"妈妈":   "mā ma",
+	"没关系":  "méi guān xi",
+	"没有":   "méi yǒu",
+	"美国":   "měi guó",
+	"妹妹":   "mèi mei",
+	"民族":   "mín zú",
+	"明年":   "míng nián",
+	"明天":   "míng tiān",
+	"名著":   "míng zhù",
+	"名字":   "míng zi",
+	"目的":   "mù dì",
+	"那个":   "nà ge",
+	"你好":   "nǐ hǎo",
+	"你们":   "nǐ men",
+	"女儿":   "nǚ ér",
+	"偶尔":   "ǒu ěr",
+	"朋友":   "péng you",
+	"皮袄":   "pí ǎo",
+	"漂亮":   "piào liang",
+	"拼音":   "pīn yīn",
+	"平安":   "píng ān",
+	"普通话":  "pǔ tōng huà",
+	"汽车":   "qì chē",
+	"亲爱":   "qīn ài",
+	"去年":   "qù nián",
+	"人民":   "rén mín",
+	"人参":   "rén shēn",
+	"认识":   "rèn shi",
+	"认为":   "rèn wéi",
+	"日本":   "rì běn",
+	"三国演义": "sān guó yǎn yì",
+	"三角":   "sān jiǎo",
+	"商店":   "shāng diàn",
+	"上海":   "shàng hǎi",
+	"上午":   "shàng wǔ",
+	"社会":   "shè huì",
+	"深圳":   "shēn zhèn",
+	"什么":   "shén me",
+	"时候":   "shí hou",
+	"时间":   "shí jiān",
+	"世界":   "shì jiè",
+	"市场":   "shì chǎng",
+	"事情":   "shì qing",
+	"手机":   "shǒu jī",
+	"首都":   "shǒu dū",
+	"书店":   "shū diàn",
+	"数据":   "shù jù",
+	"数量":   "shù liàng",
+	"数学":   "shù xué",
+	"睡觉":   "shuì jiào",
+	"睡着":   "shuì zháo",
+	"所以":   "suǒ yǐ",
+	"他们":   "tā men",
+	"她们":   "tā men",
+	"台湾":   "tái wān",
+	"天安门":  "tiān ān mén",
+	"天气":   "tiān qì",
+	"调整":   "tiáo zhěng",
+	"头发":   "tóu fa",
+	"图书馆":  "tú shū guǎn",
+	"晚安":   "wǎn ān",
+	"晚上":   "wǎn shang",
+	"为了":   "wèi le",
+	"文化":   "wén huà",
+	"文学":   "wén xué",
+	"文字":   "wén zì",
+	"问题":   "wèn tí",
+	"我们":   "wǒ men",
+	"西安":   "xī ān",
+	"西藏":   "xī zàng",
+	"喜欢":   "xǐ huan",
+	"下午":   "xià wǔ",
+	"厦门":   "xià mén",
+	"先生":   "xiān sheng",
+	"现在":   "xiàn zài",
+	"香港":   "xiāng gǎng",
+	"相信":   "xiāng xìn",
+	"小姐":   "xiǎo jiě",
+	"小学":   "xiǎo xué",
+	"谢谢":   "xiè xie",
+	"星期":   "xīng qī",
+	"行走":   "xíng zǒu",
+	"学生":   "xué sheng",
+	"学习":   "xué xí",
+	"学校":   "xué xiào",
+	"延安":   "yán ān",
+	"一定":   "yī dìng",
+	"一个":   "yī gè",
+	"一起":   "yī qǐ",
+	"一样":   "yī yàng",
+	"衣服":   "yī fu",
+	"医生":   "yī shēng",
+	"医院":   "yī yuàn",
+	"已经":   "yǐ jīng",
+	"椅子":   "yǐ zi",
+	"因为":   "yīn wèi",
+	"音乐":   "yīn yuè",
+	"银行":   "yín háng",
+	"英国":   "yīng guó",
+	"英文":   "yīng wén",
+	"应该":   "yīng gāi",
+	"应用":   "yìng yòng",
+	"语言":   "yǔ yán",
+	"再见":   "zài jiàn",
+	"早上":   "zǎo shang",
+	"怎么":   "zěn me",
+	"长大":   "zhǎng dà",
+	"着急":   "zháo jí",
+	"照相":   "zhào xiàng",
+	"这个":   "zhè ge",
+	"政府":   "zhèng fǔ",
+	"知道":   "zhī dào",
+	"只有":   "zhǐ yǒu",
+	"中国":   "zhōng guó",
+	"中国人":  "zhōng guó rén",
+	"中文":   "zhōng wén",
+	"中午":   "zhōng wǔ",
+	"中学":   "zhōng xué",
+	"种类":   "zhǒng lèi",
+	"种子":   "zhǒng zi",
+	"重要":   "zhòng yào",
+	"种地":   "zhòng dì",
+	"桌子":   "zhuō zi",
+	"字典":   "zì diǎn",
+	"自行车":  "zì xíng chē",
+	"昨天":   "zuó tiān",
+	"作为":   "zuò wéi",
+}
+
+// ProperNouns 专有名词，按正词法首字母大写
+var ProperNouns = map[string]bool{
+	"北京":   true,
+	"蚌埠":   true,
+	"长城":   true,
+	"长江":   true,
+	"长沙":   true,
+	"重庆":   true,
+	"德国":   true,
+	"法国":   true,
+	"广州":   true,
+	"汉语":   true,
+	"红楼梦":  true,
+	"黄河":   true,
+	"六安":   true,
+	"美国":   true,
+	"普通话":  true,
+	"日本":   true,
+	"三国演义": true,
+	"上海":   true,
+	"深圳":   true,
+	"台湾":   true,
+	"天安门":  true,
+	"西安":   true,
+	"西藏":   true,
+	"厦门":   true,
+	"香港":   true,
+	"延安":   true,
+	"英国":   true,
+	"中国":   true,
+	"中国人":  true,
+}
@@ -9,10 +9,10 @@
 package pinyin
 
 import (
-	"bytes"
-	"fmt"
-	"regexp"
+	"io"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-shaper/shaper"
 )
@@ -45,69 +45,69 @@ const (
 	Both          = 11       // 11: 双显风格，返回 汉字 + 拼音
 )
 
+// -- 分隔符位置 SeparatorPolicy
+const (
+	SeparatorAfter    SeparatorPolicy = iota // 每个拼音之后都加分隔符（默认），有 Normalizer 时规范化的标点之前除外。如： "1996—9yue 30ri "
+	SeparatorBetween                         // 只在相邻的拼音之间加分隔符。如： "1996—9yue30ri"
+	SeparatorBoundary                        // 在相邻的拼音之间，以及拼音与字母、数字之间加分隔符。如： "1996—9 yue 30 ri"
+)
+
+// SeparatorPolicy 配置分隔符的位置
+type SeparatorPolicy int
+
 // 声母表
 var initialArray = strings.Split(
 	"b,p,m,f,d,t,n,l,g,k,h,j,q,x,r,zh,ch,sh,z,c,s",
 	",",
 )
 
-// 所有带声调的字符
-var rePhoneticSymbolSource = func(m map[string]string) string {
-	s := ""
-	for k := range m {
-		s = s + k
-	}
-	return s
-}(phoneticSymbol)
-
-// 匹配带声调字符的正则表达式
-var rePhoneticSymbol = regexp.MustCompile("[" + rePhoneticSymbolSource + "]")
-
-// Style 配置拼音风格 (声调风格 + 部分返回)
-type Style struct {
-	tone     int // 拼音风格（默认： Normal)
-	truncate int // 部分返回
-}
-
-// Pinyin with 配置信息
+// 声母表，简明整齐的处理声母韵母 ref mozillazg/go-pinyin/issues/18
+// both y and w are considered 声母, add them back
+var initialArrayYW = append(initialArray[:len(initialArray):len(initialArray)], "y", "w")
+
+// Pinyin with 配置信息.
+// A Pinyin only holds immutable style state once constructed, so that it is
+// safe for concurrent use by multiple goroutines, as long as its exported
+// fields, and the Segmenter and Normalizer they point to, are not changed
+// meanwhile (Segmenter.AddWord is safe though). The Style fields may be
+// changed after construction, though the converters built for the style,
+// with New or NewPinyin, are the faster.
 type Pinyin struct {
 	Style
-	Separator   string // 使用的分隔符（默认：" ")
-	polyphone   bool   // 是否启用多音字模式（默认：禁用）
-	capitalized bool   // 首字母大写
-
-	shaper *Shaper
+	Separator        string          // 使用的分隔符（默认：" ")
+	SeparatorPolicy  SeparatorPolicy // 分隔符的位置（默认：SeparatorAfter）
+	BothFormat       BothFormatter   // 双显风格的输出格式（默认：汉字(拼音)）
+	Orthographic     bool            // 按正词法输出，以词为单位连写，取代逐字的 Separator
+	Segmenter        *Segmenter      // 分词器，用于词语的读音（默认：DefaultSegmenter，为 nil 则逐字转换）
+	ReadNumbers      bool            // 读出阿拉伯数字，见 Verbalize（默认：原样输出）
+	Normalizer       *Normalizer     // 标点符号与全角字符的规范化（默认：原样输出）
+	Fallback         Fallback        // 处理 PinyinDict 中没有的汉字（默认：原样输出）
+	PolyphoneTop     int             // 多音字模式下最多输出的读音数，按风格处理并去重后计数（默认：0，不限）
+	PolyphoneMinProb float64         // 多音字模式下只输出概率不低于此值的读音（默认：0，不限）
+	polyphone        bool            // 是否启用多音字模式（默认：禁用）
+
+	table *styleTable // 构造时的拼音风格的音节表，见 syllableTable
 }
 
-var finalExceptionsMap = map[string]string{
-	"ū": "ǖ",
-	"ú": "ǘ",
-	"ǔ": "ǚ",
-	"ù": "ǜ",
-}
-
-// NewPinyin 返回包含默认配置的 `Pinyin`
+// NewPinyin 返回包含默认配置的 `Pinyin`.
+// See New for the more readable, options-based constructor.
+// It is safe to construct converters concurrently, and the converters of
+// different styles don't affect each other.
 func NewPinyin(tone, truncate int, separator string, _polyphone, _capitalized bool) Pinyin {
-	a := Pinyin{Style: Style{tone, truncate},
-		Separator:   separator,
-		polyphone:   _polyphone,
-		capitalized: _capitalized,
-	}
-	if a.truncate != ZeroConsonant {
-		// 简明整齐的处理声母韵母 ref mozillazg/go-pinyin/issues/18
-		// both y and w are considered 声母, add them back
-		initialArray = append(initialArray, "y", "w")
+	a := Pinyin{Style: NewStyle(tone, truncate),
+		Separator: separator,
+		polyphone: _polyphone,
+		Segmenter: DefaultSegmenter,
 	}
-	a.shaper = NewShaper()
-	if a.truncate != Normal {
-		a.shaper.ApplyTruncate(a)
-	}
-	if a.tone != Tone3 {
-		a.shaper.ApplyToneShaping(a)
-	}
-	if a.capitalized {
-		a.shaper.ApplyTitle()
+	if _capitalized {
+		a.Case = CaseTitle
 	}
+	return a.init()
+}
+
+// init 按拼音风格构造 a 的音节表
+func (a Pinyin) init() Pinyin {
+	a.table = a.syllableTable()
 	return a
 }
 
@@ -124,121 +124,237 @@ func NewShaper() *Shaper {
 	return &Shaper{Shaper: shaper.NewShaper()}
 }
 
-// 处理 y, w
+// 处理 y, w：不带声调的拼写 p 按零声母规则还原，ü 写作 v
 func handleYW(p string) string {
+	if len(p) < 2 {
+		return p
+	}
 	// 特例 y/w
-	if strings.HasPrefix(p, "yu") {
-		p = "v" + p[2:] // yu -> v
-	} else if strings.HasPrefix(p, "yi") {
-		p = p[1:] // yi -> i
-	} else if strings.HasPrefix(p, "y") {
-		p = "i" + p[1:] // y -> i
-	} else if strings.HasPrefix(p, "wu") {
-		p = p[1:] // wu -> u
-	} else if strings.HasPrefix(p, "w") {
-		p = "u" + p[1:] // w -> u
+	switch {
+	case p[0] == 'y' && p[1] == 'u':
+		return "v" + p[2:] // yu -> v
+	case p[0] == 'y' && p[1] == 'i':
+		return p[1:] // yi -> i
+	case p[0] == 'y':
+		return "i" + p[1:] // y -> i
+	case p[0] == 'w' && p[1] == 'u':
+		return p[1:] // wu -> u
+	case p[0] == 'w':
+		return "u" + p[1:] // w -> u
 	}
 	return p
 }
 
+// ApplyToneShaping 按 a 的声调风格处理各个拼音（声调在韵母上）
 func (sp *Shaper) ApplyToneShaping(a Pinyin) *Shaper {
+	st := Style{Tone: a.Tone, Umlaut: a.Umlaut}
 	sp.AddShaper(func(p string) string {
-		if a.truncate == Initials || a.tone == Tone3 {
+		if a.Parts&(PartSyllable|PartFinal) == 0 || a.Tone == Tone3 {
 			// already shortened or no need to change
 			return p
 		}
-
-		// 替换拼音中的带声调字符
-		py := rePhoneticSymbol.ReplaceAllStringFunc(p, func(m string) string {
-			symbol, _ := phoneticSymbol[m]
-			switch a.tone {
-			// 不包含声调
-			case Normal:
-				// 去掉声调: a1 -> a 匹配使用数字标识声调的字符的正则表达式
-				m = regexp.MustCompile("([aeoiuvnm])([1-4])$").
-					ReplaceAllString(symbol, "$1")
-			case Tone2, Tone1:
-				// 返回使用数字标识声调的字符
-				m = symbol
-			default:
-				// 声调在头上
-			}
-			return m
-		})
-
-		if a.tone == Tone1 {
-			// 将声调移动到最后. 匹配 Tone2 中标识韵母声调的正则表达式
-			py = regexp.MustCompile("^([a-z]+)([1-4])([a-z]*)$").
-				ReplaceAllString(py, "$1$3$2")
+		if sy, ok := parseSyllable(p); ok {
+			return st.toned(sy.plain, sy.tone)
 		}
-		return py
+		return p
 	})
 	return sp
 }
 
+// ApplyTruncate 按 a 输出的部分处理各个拼音，声调仍在韵母上
 func (sp *Shaper) ApplyTruncate(a Pinyin) *Shaper {
+	st := a.Style
+	st.Tone, st.Case = Tone3, CaseLower
 	sp.AddShaper(func(p string) string {
-		if a.truncate == Both {
-			// 双显风格，返回 全部拼音
-			return p
+		if sy, ok := parseSyllable(p); ok && knownSyllable(sy.plain) {
+			return st.Render(sy)
 		}
+		return p
+	})
+	return sp
+}
+
+// Token 转换结果的一个片段：一个汉字及其拼音，或一段原样输出的非汉字文本
+type Token struct {
+	Text    string    `json:"text"`              // 原文
+	Han     bool      `json:"han"`               // 是否为可注音的汉字
+	Pinyin  []string  `json:"pinyin,omitempty"`  // 拼音，多音字模式下有多个读音
+	Unified string    `json:"unified,omitempty"` // 兼容汉字或部首按其统一汉字注音时，为该统一汉字，见 UnifiedDict
+	Punct   PunctKind `json:"-"`                 // 经 Normalizer 映射的标点符号的种类
+}
 
-		if a.truncate == FirstLetter {
-			// 首字母
-			return p[:1]
+// Tokens 汉字转拼音，返回结构化的转换结果.
+// Each Han rune found in PinyinDict becomes its own Token, with its distinct
+// readings shaped according to the Pinyin style; any other runs of text are returned
+// as-is in a single non-Han Token. The runs of Han runes are segmented into
+// words with the Pinyin Segmenter, so as to read them with the word readings.
+func (a Pinyin) Tokens(s string) []Token {
+	tokens, _ := a.tokens(s)
+	return tokens
+}
+
+// tokens 同 Tokens，并返回 Fallback 的第一个错误
+func (a Pinyin) tokens(s string) ([]Token, error) {
+	tokens := []Token{}
+	err := a.scan(s, func(t Token) {
+		if t.Han {
+			t.Pinyin = append([]string(nil), t.Pinyin...)
 		}
+		tokens = append(tokens, t)
+	})
+	return tokens, err
+}
 
-		// 获取拼音中的声母
-		s, y := "", ""
-		for _, v := range initialArray {
-			if strings.HasPrefix(p, v) {
-				s = v
-				y = p[len(s):]
-				break
+// scan 汉字转拼音，依次把各个 Token 交给 yield.
+// The Pinyin of the Han Tokens is only valid during the call to yield,
+// as its storage is reused for the following Tokens.
+// It returns the first error of the Fallback, if any.
+func (a Pinyin) scan(s string, yield func(Token)) (err error) {
+	orig, at := s, 0 // 原文及其中已处理的字节数，用于 Fallback
+	if a.Normalizer != nil {
+		s = a.Normalizer.foldWidth(s)
+	}
+	if a.ReadNumbers {
+		s = Verbalize(s)
+	}
+	var readings []string
+	for text := s; len(s) > 0; {
+		if n := a.spanOther(s); n > 0 && a.Normalizer != nil {
+			a.Normalizer.eachToken(s[:n], yield)
+			s = s[n:]
+			continue
+		} else if n > 0 {
+			yield(Token{Text: s[:n]})
+			s = s[n:]
+			continue
+		}
+		if r, size := utf8.DecodeRuneInString(s); !isHan(r) {
+			// PinyinDict 中没有的汉字
+			i := len(text) - len(s)
+			if text != orig {
+				i = originOffset(orig, at, r)
 			}
+			at = i + size
+			var e error
+			readings, e = a.fallback(r, orig, i, readings, yield)
+			if err == nil {
+				err = e
+			}
+			s = s[size:]
+			continue
+		}
+		n := spanHan(s, true)
+		if n == utf8.RuneLen(firstRune(s)) {
+			// 单字无需分词
+			readings = a.scanWord(s[:n], readings, yield)
+			s = s[n:]
+			continue
 		}
+		a.eachWord(s[:n], func(w string) {
+			readings = a.scanWord(w, readings, yield)
+		})
+		s = s[n:]
+	}
+	return err
+}
 
-		if a.truncate == Initials {
-			// 声母风格
-			return s
+// scanWord 依次把词语 w 的各个汉字的 Token 交给 yield,
+// 用 readings 存放读音，返回 readings 以便重用
+func (a Pinyin) scanWord(w string, readings []string, yield func(Token)) []string {
+	phrase := a.phraseSyllables(w)
+	for j, r := range w {
+		var py string
+		py, phrase = nextField(phrase)
+		readings = readings[:0]
+		switch {
+		case a.polyphone && py != "":
+			// 多音字模式下按概率排列读音，词语读音优先
+			readings = a.rankedReadings(readings, r, py)
+		case a.polyphone:
+			readings = a.rankedReadings(readings, r, "")
+		case py != "":
+			readings = append(readings, py)
+		default:
+			readings = append(readings, firstReading(r))
+		}
+		t := Token{Text: w[j : j+utf8.RuneLen(r)], Han: true,
+			Pinyin: a.top(distinct(a.shape(readings)))}
+		if u := Unified(r); u != r {
+			t.Unified = string(u)
 		}
+		yield(t)
+	}
+	return readings
+}
+
+// firstReading 返回汉字 r 在 PinyinDict 中的第一个读音
+func firstReading(r rune) string {
+	value := PinyinDict[int(Unified(r))]
+	if i := strings.IndexByte(value, ','); i >= 0 {
+		return value[:i]
+	}
+	return value
+}
 
-		// 韵母风格
+// Unified 返回兼容汉字或部首 r 的统一汉字，见 UnifiedDict; 其它字符原样返回
+func Unified(r rune) rune {
+	if r < 0x2E80 || r >= 0x2FE0 && r < 0xF900 || r >= 0xFB00 && r < 0x2F800 {
+		// 不在兼容汉字及部首的区间
+		return r
+	}
+	if u, ok := UnifiedDict[int(r)]; ok {
+		return rune(u)
+	}
+	return r
+}
 
-		// 转换为 []rune unicode 编码用于获取第一个拼音字符
-		// 因为 string 是 utf-8 编码不方便获取第一个拼音字符
-		rs := []rune(p)
-		switch string(rs[0]) {
-		// 因为鼻音没有声母所以不需要去掉声母部分
-		case "ḿ", "ń", "ň", "ǹ":
-			return p
-		}
+// isHan tells whether r is a Han rune found in PinyinDict,
+// directly or through its unified ideograph
+func isHan(r rune) bool {
+	_, ok := PinyinDict[int(Unified(r))]
+	return ok && r > '~'
+}
 
-		// ǖ 特例 j/q/x/y
-		matches := regexp.MustCompile("^(j|q|x|y)(ū|ú|ǔ|ù)$").FindStringSubmatch(p)
-		// jū -> jǖ
-		if len(matches) == 3 && matches[1] != "" && matches[2] != "" {
-			y, _ = finalExceptionsMap[matches[2]]
-		}
-		matches = regexp.MustCompile("^(j|q|x|y)(u)").FindStringSubmatch(p)
-		// yuán -> yván
-		if len(matches) == 3 && matches[1] != "" && matches[2] != "" {
-			y = "v" + p[2:] // yu -> v
+// spanHan 返回 s 开头连续的 (han 为真时) 汉字或 (han 为假时) 非汉字的长度
+func spanHan(s string, han bool) int {
+	for i, r := range s {
+		if isHan(r) != han {
+			return i
 		}
+	}
+	return len(s)
+}
 
-		// 简明整齐的处理声母韵母
-		if a.truncate == Finals {
-			return y
-		}
+// readings 返回汉字 r 按拼音风格处理后的全部不同读音
+func (a Pinyin) readings(r rune) []string {
+	value, ok := PinyinDict[int(Unified(r))]
+	if !ok {
+		return nil
+	}
+	return distinct(a.shape(strings.Split(value, ",")))
+}
 
-		// 获取拼音中的韵母
-		if s == "" {
-			y = handleYW(p)
-		}
+// shape 按拼音风格处理各个读音
+func (a Pinyin) shape(readings []string) []string {
+	for i := range readings {
+		readings[i] = a.shapeOne(readings[i])
+	}
+	return readings
+}
 
-		return y
-	})
-	return sp
+// distinct 去掉重复的读音，保持原有次序
+func distinct(readings []string) []string {
+	pys := readings[:0]
+	for _, py := range readings {
+		dup := false
+		for _, p := range pys {
+			dup = dup || p == py
+		}
+		if !dup {
+			pys = append(pys, py)
+		}
+	}
+	return pys
 }
 
 // Convert 汉字转拼音，支持多音字模式.
@@ -246,32 +362,143 @@ func (sp *Shaper) ApplyTruncate(a Pinyin) *Shaper {
 // E.g., for input like "我的银行不行", the output is
 // wo de yin hang/xing bu hang/xing.
 func (a Pinyin) Convert(s string) string {
-	pys := bytes.NewBufferString("")
-	for _, r := range s {
-		if r <= '~' {
-			pys.WriteString(string(r))
-			continue
+	return string(a.AppendConvert(nil, s))
+}
+
+// ConvertBytes 汉字转拼音，同 Convert，输入输出为 []byte
+func (a Pinyin) ConvertBytes(b []byte) []byte {
+	return a.AppendConvert(nil, string(b))
+}
+
+// ConvertTo 汉字转拼音，同 Convert，结果写入 w.
+// The result is written as it is converted, in chunks of about 4KB,
+// and the first error of w stops the writing.
+func (a Pinyin) ConvertTo(w io.Writer, s string) (int, error) {
+	written := 0
+	var err error
+	flush := func(dst []byte) []byte {
+		if err == nil && len(dst) > 0 {
+			var n int
+			n, err = w.Write(dst)
+			written += n
 		}
-		value, ok := PinyinDict[int(r)]
-		if !ok {
-			pys.WriteString(string(r))
-			continue
+		return dst[:0]
+	}
+	dst, _ := a.convert(make([]byte, 0, convertChunk+64), s, flush)
+	flush(dst)
+	return written, err
+}
+
+// ConvertTo 每次写入的字节数
+const convertChunk = 4096
+
+// AppendConvert 汉字转拼音，同 Convert，结果追加到 dst 并返回.
+// Reusing dst across calls avoids allocating the output, and the conversion
+// itself doesn't allocate per rune, except in polyphone mode and with
+// Orthographic or ReadNumbers.
+func (a Pinyin) AppendConvert(dst []byte, s string) []byte {
+	dst, _ = a.appendConvert(dst, s)
+	return dst
+}
+
+// appendConvert 同 AppendConvert，返回 Fallback 的第一个错误
+func (a Pinyin) appendConvert(dst []byte, s string) ([]byte, error) {
+	return a.convert(dst, s, nil)
+}
+
+// convert 汉字转拼音，结果追加到 dst 并返回，以及 Fallback 的第一个错误.
+// If flush is not nil, it is called with dst whenever dst grows over
+// convertChunk, and returns dst to go on with.
+func (a Pinyin) convert(dst []byte, s string, flush func([]byte) []byte) ([]byte, error) {
+	if a.Orthographic {
+		py, err := a.orthography(s)
+		return append(dst, py...), err
+	}
+	first := true
+	after := false // SeparatorAfter 下拼音之后尚未输出的分隔符
+	var prev Token
+	err := a.scan(s, func(t Token) {
+		if flush != nil && len(dst) >= convertChunk {
+			dst = flush(dst)
 		}
-		firstComma := strings.Index(value, ",")
-		if !a.polyphone && firstComma > 0 {
-			value = value[:firstComma]
+		switch {
+		case a.SeparatorPolicy != SeparatorAfter:
+			if !first && a.separated(prev, t) {
+				dst = append(dst, a.Separator...)
+			}
+		case after && a.Normalizer != nil && (t.Punct == PunctClose || t.Punct == PunctJoin):
+			// 规范化的标点之前不加分隔符
+		case after:
+			dst = append(dst, a.Separator...)
+		case a.Normalizer != nil && prev.Punct != PunctNone && a.separated(prev, t):
+			// 规范化的标点之后同 SeparatorBetween
+			dst = append(dst, a.Separator...)
 		}
-		// 多音字模式 (Polyphone), output likes "hang/xing"
-		if a.polyphone && firstComma > 0 {
-			value = strings.Replace(value, ",", "/", -1)
+		first, prev, after = false, t, false
+		if !t.Han {
+			dst = append(dst, t.Text...)
+			return
 		}
-		py := a.shaper.Process(value)
-		if a.truncate == Both {
+		if a.hanzi() && a.BothFormat != nil {
+			r, _ := utf8.DecodeRuneInString(t.Text)
+			dst = append(dst, a.BothFormat(r, strings.Join(t.Pinyin, "/"), a.readings(r))...)
+		} else if a.hanzi() {
 			// 双显风格
-			fmt.Fprintf(pys, "%s(%s)%s", string(r), py, a.Separator)
+			dst = append(dst, t.Text...)
+			dst = append(dst, '(')
+			dst = appendReadings(dst, t.Pinyin)
+			dst = append(dst, ')')
 		} else {
-			pys.WriteString(py + a.Separator)
+			dst = appendReadings(dst, t.Pinyin)
 		}
+		after = a.SeparatorPolicy == SeparatorAfter
+	})
+	if after {
+		dst = append(dst, a.Separator...)
+	}
+	return dst, err
+}
+
+// appendReadings 把读音追加到 dst，多音字模式 (Polyphone) 下形如 "hang/xing"
+func appendReadings(dst []byte, readings []string) []byte {
+	for i, py := range readings {
+		if i > 0 {
+			dst = append(dst, '/')
+		}
+		dst = append(dst, py...)
+	}
+	return dst
+}
+
+// separated tells whether the separator goes between the adjacent tokens
+// prev and next, under the SeparatorBetween and SeparatorBoundary policies.
+// Punctuation mapped by the Normalizer is spaced out like in Latin text.
+func (a Pinyin) separated(prev, next Token) bool {
+	switch {
+	case prev.Han && next.Han:
+		return true
+	case prev.Punct == PunctOpen || prev.Punct == PunctJoin,
+		next.Punct == PunctClose || next.Punct == PunctJoin:
+		return false
+	case prev.Punct == PunctClose:
+		return next.Han || next.Punct == PunctOpen || isAlnum(firstRune(next.Text))
+	case next.Punct == PunctOpen:
+		return prev.Han || isAlnum(lastRune(prev.Text))
+	case a.SeparatorPolicy != SeparatorBoundary:
+		return false
+	case prev.Han:
+		return isAlnum(firstRune(next.Text))
 	}
-	return pys.String()
+	return next.Han && isAlnum(lastRune(prev.Text))
+}
+
+// isAlnum tells whether r is a letter or digit
+func isAlnum(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// lastRune 返回 s 的最后一个字符
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
 }
@@ -1,11 +1,18 @@
 package pinyin
 
 import (
+	"bytes"
+	"errors"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 type pinyinFunc func(string) [][]string
 
+// raceEnabled 是否启用了竞态检测，其下 sync.Pool 会随机丢弃缓冲区，见 race_test.go
+var raceEnabled bool
+
 type testCase struct {
 	a      Pinyin
 	result string
@@ -158,7 +165,7 @@ func TestUpdated(t *testing.T) {
 		// {"呀", NewPinyin(Normal, Initials, Separator, false, false), " "},
 		{"呀", NewPinyin(Tone2, Normal, Separator, false, false), "ya "},
 		{"呀", NewPinyin(Tone1, Normal, Separator, false, false), "ya "},
-		// {"呀", NewPinyin(Normal, ZeroConsonant, Separator, false, false), "ia "},
+		{"呀", NewPinyin(Normal, ZeroConsonant, Separator, false, false), "ia "},
 		// {"无", NewPinyin(Normal, Initials, Separator, false, false), " "},
 		{"无", NewPinyin(Tone2, Normal, Separator, false, false), "wu2 "},
 		{"无", NewPinyin(Tone1, Normal, Separator, false, false), "wu2 "},
@@ -168,7 +175,7 @@ func TestUpdated(t *testing.T) {
 		{"衣", NewPinyin(Normal, ZeroConsonant, Separator, false, false), "i "},
 		{"万", NewPinyin(Tone2, Normal, Separator, false, false), "wa4n "},
 		{"万", NewPinyin(Tone1, Normal, Separator, false, false), "wan4 "},
-		// {"万", NewPinyin(Normal, ZeroConsonant, Separator, false, false), "uan "},
+		{"万", NewPinyin(Normal, ZeroConsonant, Separator, false, false), "uan "},
 		// ju, qu, xu 的韵母应该是 v
 		{"具", NewPinyin(Tone3, ZeroConsonant, Separator, false, false), "ǜ "},
 		{"具", NewPinyin(Tone2, ZeroConsonant, Separator, false, false), "v4 "},
@@ -217,3 +224,153 @@ func TestUpdated(t *testing.T) {
 	}
 	testPinyinUpdate(t, testData)
 }
+
+func TestSeparatorPolicy(t *testing.T) {
+	between := NewPinyin(Normal, Normal, " ", false, false)
+	between.SeparatorPolicy = SeparatorBetween
+	boundary := NewPinyin(Normal, Normal, " ", false, false)
+	boundary.SeparatorPolicy = SeparatorBoundary
+	both := NewPinyin(Tone3, Both, " ", false, true)
+	both.SeparatorPolicy = SeparatorBoundary
+	after := NewPinyin(Normal, Normal, " ", false, false)
+	testData := []testItem{
+		{"1996—9月30日", after, "1996—9yue 30ri "},
+		{"中国人", between, "zhong guo ren"},
+		{"〖中国银行〗，很.行.。", between, "〖zhong guo yin hang〗，hen.xing.。"},
+		{"1996—9月30日", between, "1996—9yue30ri"},
+		{"中国", boundary, "zhong guo"},
+		{"〖中国银行〗，很.行.。", boundary, "〖zhong guo yin hang〗，hen.xing.。"},
+		{"1996—9月30日", boundary, "1996—9 yue 30 ri"},
+		{"用Go写", boundary, "yong Go xie"},
+		{"中国 银行", boundary, "zhong guo yin hang"},
+		{"中国银行。", both, "中(Zhōng) 国(Guó) 银(Yín) 行(Háng)。"},
+	}
+	testPinyinUpdate(t, testData)
+}
+
+func TestConcurrency(t *testing.T) {
+	hans := "中国人的〖中国银行〗，很.行.。"
+	styles := [][2]int{
+		{Normal, Normal}, {Tone1, Normal}, {Tone2, ZeroConsonant}, {Tone3, Finals},
+		{Normal, Initials}, {Tone3, ZeroConsonant}, {Tone1, FirstLetter}, {Tone3, Both},
+	}
+	expected := make([]string, len(styles))
+	for i, st := range styles {
+		expected[i] = NewPinyin(st[0], st[1], " ", true, false).Convert(hans)
+	}
+
+	sg := NewSegmenter()
+	done := make(chan bool)
+	for n := 0; n < 4; n++ {
+		go func(n int) {
+			for i := range styles {
+				st := styles[(i+n)%len(styles)]
+				a := NewPinyin(st[0], st[1], " ", true, false)
+				a.Segmenter = sg
+				sg.AddWord("很行", "hěn xíng", 0)
+				if v := a.Convert(hans); v != expected[(i+n)%len(styles)] {
+					t.Errorf("style %v expects '%s', got '%s'", st, expected[(i+n)%len(styles)], v)
+				}
+			}
+			done <- true
+		}(n)
+	}
+	for n := 0; n < 4; n++ {
+		<-done
+	}
+
+	// 零声母风格不受其它风格的影响
+	NewPinyin(Normal, Initials, " ", false, false)
+	if v := NewPinyin(Normal, ZeroConsonant, " ", false, false).Convert("呀万"); v != "ia uan " {
+		t.Errorf(`Expected "ia uan ", got "%s"`, v)
+	}
+}
+
+func TestAppendConvert(t *testing.T) {
+	hans := "中国人的〖中国银行〗，很.行.。9月30日"
+	testData := []struct {
+		tone, truncate int
+		polyphone      bool
+		expected       string
+	}{
+		{Normal, Normal, false, "Zhong-Guo-Ren-De〖Zhong-Guo-Yin-Hang〗，Hen.Xing.。9-Yue-30-Ri"},
+		{Normal, Normal, true, "Zhong-Guo-Ren-De/Di〖Zhong-Guo-Yin-Hang/Xing/Heng〗，Hen.Xing/Hang/Heng.。9-Yue/Ru-30-Ri"},
+		{Tone1, Normal, false, "Zhong1-Guo2-Ren2-De〖Zhong1-Guo2-Yin2-Hang2〗，Hen3.Xing2.。9-Yue4-30-Ri4"},
+		{Tone2, ZeroConsonant, false, "O1ng-Uo2-E2n-E〖O1ng-Uo2-I2n-A2ng〗，E3n.I2ng.。9-Ve4-30-I4"},
+		{Tone3, Finals, false, "Ōng-Uó-Én-E〖Ōng-Uó-Ín-Áng〗，Ěn.Íng.。9-Üè-30-Ì"},
+		{Normal, Initials, true, "Zh-G-R-D〖Zh-G-Y-H/X〗，H.X/H.。9-Y/R-30-R"},
+		{Tone1, FirstLetter, false, "Z-G-R-D〖Z-G-Y-H〗，H.X.。9-Y-30-R"},
+		{Tone3, Both, false, "中(Zhōng)-国(Guó)-人(Rén)-的(De)〖中(Zhōng)-国(Guó)-银(Yín)-行(Háng)〗，很(Hěn).行(Xíng).。9-月(Yuè)-30-日(Rì)"},
+	}
+	for _, tc := range testData {
+		a := NewPinyin(tc.tone, tc.truncate, "-", tc.polyphone, true)
+		a.SeparatorPolicy = SeparatorBoundary
+		if v := a.Convert(hans); v != tc.expected {
+			t.Errorf("Convert %+v expects '%s', got '%s'", tc, tc.expected, v)
+		}
+		if v := string(a.AppendConvert([]byte("> "), hans)); v != "> "+tc.expected {
+			t.Errorf("AppendConvert %+v expects '> %s', got '%s'", tc, tc.expected, v)
+		}
+		if v := string(a.ConvertBytes([]byte(hans))); v != tc.expected {
+			t.Errorf("ConvertBytes %+v expects '%s', got '%s'", tc, tc.expected, v)
+		}
+		var buf bytes.Buffer
+		if n, err := a.ConvertTo(&buf, hans); err != nil || n != len(tc.expected) || buf.String() != tc.expected {
+			t.Errorf("ConvertTo %+v expects '%s', got '%s' (%d, %v)", tc, tc.expected, buf.String(), n, err)
+		}
+	}
+
+	// 不分配内存，包括分词及标点符号的规范化
+	mixed := strings.Repeat("我们去中国银行，很好。Go语言(2017)！", 50)
+	for _, n := range []*Normalizer{nil, NewNormalizer()} {
+		for _, s := range []string{hans500, mixed} {
+			a := NewPinyin(Tone1, Normal, " ", false, false)
+			a.Normalizer = n
+			dst := a.AppendConvert(nil, s)
+			allocs := testing.AllocsPerRun(10, func() {
+				dst = a.AppendConvert(dst[:0], s)
+			})
+			if allocs > 2 && !raceEnabled {
+				t.Errorf("AppendConvert allocates %v times for %d runes", allocs, utf8.RuneCountInString(s))
+			}
+		}
+	}
+	a := NewPinyin(Normal, Normal, " ", false, false)
+	a.Normalizer = NewNormalizer()
+	a.SeparatorPolicy = SeparatorBoundary
+	if v := string(a.AppendConvert(nil, mixed[:len(mixed)/50])); v != "wo men qu zhong guo yin hang, hen hao. Go yu yan (2017)!" {
+		t.Errorf("Expected 'wo men qu zhong guo yin hang, hen hao. Go yu yan (2017)!', got '%s'", v)
+	}
+
+	// ConvertTo 边转换边写入
+	long := strings.Repeat(hans500, 20)
+	w := &chunkWriter{}
+	if n, err := a.ConvertTo(w, long); err != nil || n != len(w.buf.String()) || w.buf.String() != a.Convert(long) {
+		t.Errorf("ConvertTo writes %d bytes (%v), expects %d", n, err, len(a.Convert(long)))
+	}
+	if w.writes < 2 || w.max > 2*convertChunk {
+		t.Errorf("ConvertTo writes %d times, at most %d bytes", w.writes, w.max)
+	}
+	w = &chunkWriter{fail: 2}
+	if n, err := a.ConvertTo(w, long); err == nil || n != w.buf.Len() || w.writes != 2 {
+		t.Errorf("ConvertTo expects to stop at the error, wrote %d bytes in %d times (%v)", n, w.writes, err)
+	}
+}
+
+// chunkWriter 记录写入的次数，第 fail 次写入时出错
+type chunkWriter struct {
+	buf         bytes.Buffer
+	writes, max int
+	fail        int
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes == w.fail {
+		return 0, errors.New("write failed")
+	}
+	if len(p) > w.max {
+		w.max = len(p)
+	}
+	return w.buf.Write(p)
+}
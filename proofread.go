@@ -0,0 +1,282 @@
+package pinyin
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// IssueKind 校对问题的类别
+type IssueKind string
+
+// -- 校对问题的类别 IssueKind
+const (
+	IssueInvalid    IssueKind = "invalid"    // 不是拼音音节。如： zhongg
+	IssueUnattested IssueKind = "unattested" // 该字没有这个读音。如： 中(guo2)
+	IssueTone       IssueKind = "tone"       // 该字有这个音节，但声调不对。如： 中(zhong3)
+	IssueCount      IssueKind = "count"      // 音节数与汉字数不符
+	IssueContext    IssueKind = "context"    // 多音字，按所在词语应读另一个音。如： 银行(yin2 xing2)
+)
+
+// Issue 校对发现的一个问题
+type Issue struct {
+	Kind     IssueKind `json:"kind"`
+	Offset   int       `json:"offset"`             // 汉字在 Report.Text 中的字节位置
+	Hanzi    string    `json:"hanzi,omitempty"`    // 汉字
+	Pinyin   string    `json:"pinyin,omitempty"`   // 校对的拼音
+	Expected []string  `json:"expected,omitempty"` // 建议的读音，声调在韵母上
+	Word     string    `json:"word,omitempty"`     // 所在的词语，见 IssueContext
+	Message  string    `json:"message"`
+}
+
+// Report 拼音校对的结果，可直接编码为 JSON
+type Report struct {
+	Text      string  `json:"text"`      // 校对的汉字文本
+	Hanzi     int     `json:"hanzi"`     // 汉字数
+	Syllables int     `json:"syllables"` // 音节数
+	Issues    []Issue `json:"issues"`    // 发现的问题，按位置排列
+}
+
+// OK tells whether no issue is found
+func (rp Report) OK() bool {
+	return len(rp.Issues) == 0
+}
+
+// proofItem 一个汉字及其注音
+type proofItem struct {
+	offset int
+	r      rune
+	pinyin []string // 注音，多音字注音的各个读音；nil 为未注音
+}
+
+// Proofread 校对汉字文本 hans 的拼音 pinyin (不逐字对齐).
+// The pinyin may be in any tone style, with the syllables separated by
+// spaces, apostrophes or punctuation, or not at all. If the syllables don't
+// add up to the Hanzi, a single IssueCount is reported, with the reading of
+// the text as expected; otherwise each syllable is checked against the
+// readings of its Hanzi in PinyinDict, and against the reading of the word
+// it is in, from PhraseDict or the Segmenter. A syllable read so in the word,
+// e.g., with the neutral tone, passes even if PinyinDict doesn't list it. The
+// tones are only checked if the pinyin has any. The other text of hans is
+// ignored.
+func (a Pinyin) Proofread(hans, pinyin string) Report {
+	rp := Report{Text: hans, Issues: []Issue{}}
+	items := []proofItem{}
+	for i, r := range hans {
+		if proofHan(r) {
+			items = append(items, proofItem{offset: i, r: r})
+		}
+	}
+	syllables := proofSyllables(pinyin)
+	rp.Hanzi, rp.Syllables = len(items), len(syllables)
+	if len(syllables) != len(items) {
+		rp.Issues = append(rp.Issues, Issue{Kind: IssueCount,
+			Pinyin:   strings.Join(syllables, " "),
+			Expected: a.textReading(hans),
+			Message: fmt.Sprintf("%d syllables for %d Hanzi",
+				len(syllables), len(items))})
+		return rp
+	}
+	for i := range items {
+		items[i].pinyin = syllables[i : i+1]
+	}
+	rp.Issues = a.check(hans, items, rp.Issues)
+	return rp
+}
+
+// ProofreadAnnotated 校对逐字注音的文本 s，如 Both 风格的输出或 HTML ruby，
+// 见 ParseAnnotated.
+// Each annotated Hanzi is checked as Proofread does, and so is each reading
+// of a polyphone annotation, though not against the word. The Hanzi not
+// annotated are left unchecked, but still count as the context of the
+// others. An annotation that doesn't split into one syllable per Hanzi is
+// reported as IssueCount. The Offsets are into the Report.Text, i.e., s with
+// the annotations removed.
+func (a Pinyin) ProofreadAnnotated(s string) Report {
+	rp := Report{Issues: []Issue{}}
+	items := []proofItem{}
+	for _, an := range ParseAnnotated(s) {
+		offset := len(rp.Text)
+		rp.Text += an.Text
+		n := 0
+		for i, r := range an.Text {
+			if proofHan(r) {
+				items = append(items, proofItem{offset: offset + i, r: r})
+				n++
+			}
+		}
+		if an.Pinyin == nil {
+			continue
+		}
+		if n == 1 {
+			items[len(items)-1].pinyin = an.Pinyin
+			rp.Syllables++
+			continue
+		}
+		rp.Syllables += len(proofSyllables(strings.Join(an.Pinyin, " ")))
+		rp.Issues = append(rp.Issues, Issue{Kind: IssueCount, Offset: offset,
+			Hanzi: an.Text, Pinyin: strings.Join(an.Pinyin, " "),
+			Expected: a.textReading(an.Text),
+			Message:  fmt.Sprintf("%q is annotated as a whole", an.Text)})
+	}
+	rp.Hanzi = len(items)
+	rp.Issues = a.check(rp.Text, items, rp.Issues)
+	return rp
+}
+
+// check 逐字校对 items 的注音，text 为其所在的文本，发现的问题添加到 issues
+func (a Pinyin) check(text string, items []proofItem, issues []Issue) []Issue {
+	// 有声调的注音才校对声调
+	toned := false
+	for _, it := range items {
+		for _, py := range it.pinyin {
+			if sy, err := ParseSyllable(py); err == nil && sy.tone != 0 {
+				toned = true
+			}
+		}
+	}
+	same := func(x, y Syllable) bool {
+		return x.plain == y.plain && (!toned || x.tone == y.tone)
+	}
+
+	contexts := a.contextReadings(text)
+	for _, it := range items {
+		hanzi := string(it.r)
+		for _, py := range it.pinyin {
+			issue := Issue{Offset: it.offset, Hanzi: hanzi, Pinyin: py}
+			sy, err := ParseSyllable(py)
+			if err != nil {
+				issue.Kind = IssueInvalid
+				issue.Message = fmt.Sprintf("%q is not a pinyin syllable", py)
+				issues = append(issues, issue)
+				continue
+			}
+
+			// 与词语的读音相同，包括轻声及变调
+			ctx, inWord := contexts[it.offset]
+			csy, err := ParseSyllable(ctx.reading)
+			if inWord = inWord && err == nil; inWord && same(sy, csy) {
+				continue
+			}
+
+			readings := []string{}
+			tones := []string{}
+			attested := false
+			for _, rd := range Readings(it.r) {
+				readings = append(readings, rd.Pinyin)
+				rsy, err := ParseSyllable(rd.Pinyin)
+				switch {
+				case err != nil:
+				case same(sy, rsy):
+					attested = true
+				case sy.plain == rsy.plain:
+					tones = append(tones, rd.Pinyin)
+				}
+			}
+			switch {
+			case len(readings) == 0:
+				// PinyinDict 中没有的汉字，无从校对
+			case attested:
+				if !inWord || len(it.pinyin) > 1 || len(readings) < 2 ||
+					toneSandhi[it.r] && sy.plain == csy.plain {
+					continue
+				}
+				issue.Kind = IssueContext
+				issue.Expected = []string{ctx.reading}
+				issue.Word = ctx.word
+				issue.Message = fmt.Sprintf("%q reads %s in %q", hanzi, ctx.reading, ctx.word)
+				issues = append(issues, issue)
+			case len(tones) > 0:
+				issue.Kind = IssueTone
+				issue.Expected = tones
+				issue.Message = fmt.Sprintf("%q reads %s, not %s",
+					hanzi, strings.Join(tones, ", "), sy)
+				issues = append(issues, issue)
+			default:
+				issue.Kind = IssueUnattested
+				issue.Expected = readings
+				issue.Message = fmt.Sprintf("%q reads %s, not %s",
+					hanzi, strings.Join(readings, ", "), sy)
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
+
+// 按变调注音的字，如 yí gè, bú shì，词语中的读音仍为本调
+var toneSandhi = map[rune]bool{'一': true, '不': true}
+
+// proofContext 汉字在词语中的读音
+type proofContext struct {
+	word    string
+	reading string
+}
+
+// contextReadings 返回 text 中词语的各个字的读音，以字节位置为键，
+// 只包括 PhraseDict 或分词器中有读音的词语
+func (a Pinyin) contextReadings(text string) map[int]proofContext {
+	contexts := map[int]proofContext{}
+	for i := 0; i < len(text); {
+		n := spanHan(text[i:], true)
+		if n == 0 {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			i += size
+			continue
+		}
+		for _, w := range a.words(text[i : i+n]) {
+			readings := a.phraseReading(w)
+			j := 0
+			for k := range w {
+				if j < len(readings) {
+					contexts[i+k] = proofContext{word: w, reading: readings[j]}
+				}
+				j++
+			}
+			i += len(w)
+		}
+	}
+	return contexts
+}
+
+// textReading 返回汉字文本 s 按词语的读音，声调在韵母上
+func (a Pinyin) textReading(s string) []string {
+	readings := []string{}
+	for len(s) > 0 {
+		n := spanHan(s, true)
+		if n == 0 {
+			_, n = utf8.DecodeRuneInString(s)
+			s = s[n:]
+			continue
+		}
+		for _, w := range a.words(s[:n]) {
+			readings = append(readings, a.wordSyllables(w)...)
+		}
+		s = s[n:]
+	}
+	return readings
+}
+
+// proofHan tells whether r is a Hanzi to be annotated
+func proofHan(r rune) bool {
+	return isHan(r) || unicode.Is(unicode.Han, r)
+}
+
+// proofSyllables 把不逐字对齐的拼音 s 拆成音节：以空白、隔音符号或标点分开，
+// 连写的拼音按 splitSyllables 拆开，无法拆开或长于 maxJoinedPinyin 的保留原样
+func proofSyllables(s string) []string {
+	syllables := []string{}
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) && r != ':'
+	}) {
+		if _, err := ParseSyllable(f); err == nil || len(f) > maxJoinedPinyin {
+			syllables = append(syllables, f)
+		} else if split := splitSyllables(f, 0); split != nil {
+			syllables = append(syllables, split...)
+		} else {
+			syllables = append(syllables, f)
+		}
+	}
+	return syllables
+}
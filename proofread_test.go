@@ -0,0 +1,111 @@
+package pinyin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestProofread(t *testing.T) {
+	a := NewPinyin(Tone3, Normal, " ", false, false)
+	testData := []struct {
+		hans, pinyin string
+		expected     []IssueKind
+	}{
+		{"中国人", "zhōng guó rén", nil},
+		{"中国人", "zhōngguórén", nil},
+		{"中国人", "zhong1 guo2 ren2", nil},
+		{"中国人", "zhong guo ren", nil},
+		{"西安", "Xī'ān", nil},
+		{"我去中国银行。", "Wǒ qù Zhōngguó Yínháng.", nil},
+		{"东西很好", "dōngxi hěn hǎo", nil},
+		{"朋友", "péngyou", nil},
+		{"一个不是", "yí gè bú shì", nil},
+		{"中国", "zhong3 guo2", []IssueKind{IssueTone}},
+		{"中国", "zhong gu", []IssueKind{IssueUnattested}},
+		{"中国", "zhong guoq", []IssueKind{IssueInvalid}},
+		{"中国人", "zhong guo", []IssueKind{IssueCount}},
+		{"我去中国银行", "wo3 qu4 zhong3 guo2 yin2 xing2", []IssueKind{IssueTone, IssueContext}},
+		{"我去中国银行", "wo qu zhong guo yin xing", []IssueKind{IssueContext}},
+		{"中国", "", []IssueKind{IssueCount}},
+		{"", "", nil},
+	}
+	for _, tc := range testData {
+		rp := a.Proofread(tc.hans, tc.pinyin)
+		kinds := []IssueKind{}
+		for _, is := range rp.Issues {
+			kinds = append(kinds, is.Kind)
+		}
+		if fmt.Sprint(kinds) != fmt.Sprint(tc.expected) ||
+			rp.OK() != (len(tc.expected) == 0) {
+			t.Errorf("'%s' (%s) expects %v, got %+v", tc.hans, tc.pinyin, tc.expected, rp.Issues)
+		}
+	}
+
+	rp := a.Proofread("我去中国银行。", "wo3 qu4 zhong3 guo2 yin2 xing2.")
+	b, _ := json.Marshal(rp)
+	expected := `{"text":"我去中国银行。","hanzi":6,"syllables":6,"issues":[` +
+		`{"kind":"tone","offset":6,"hanzi":"中","pinyin":"zhong3","expected":["zhōng","zhòng"],` +
+		`"message":"\"中\" reads zhōng, zhòng, not zhǒng"},` +
+		`{"kind":"context","offset":15,"hanzi":"行","pinyin":"xing2","expected":["háng"],"word":"银行",` +
+		`"message":"\"行\" reads háng in \"银行\""}]}`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, b)
+	}
+
+	rp = a.Proofread("中国人", "zhong guo")
+	if rp.Hanzi != 3 || rp.Syllables != 2 ||
+		strings.Join(rp.Issues[0].Expected, " ") != "zhōng guó rén" {
+		t.Errorf("Unexpected %+v", rp)
+	}
+
+	// 无法拆开的长串
+	for _, py := range []string{
+		strings.Repeat("na", 14) + "q",
+		strings.Repeat("nāna", 15) + "q",
+		strings.Repeat("nana", 16<<10) + "q",
+	} {
+		rp := a.Proofread("中国", "zhong "+py)
+		if rp.Syllables != 2 || len(rp.Issues) != 1 || rp.Issues[0].Kind != IssueInvalid {
+			t.Errorf("'%.20s...' expects an invalid syllable, got %+v", py, rp.Issues)
+		}
+	}
+
+	// 不按词语校对
+	a.Segmenter = nil
+	if rp := a.Proofread("银行", "yin2 xing2"); !rp.OK() {
+		t.Errorf("Unexpected %+v", rp.Issues)
+	}
+}
+
+func TestProofreadAnnotated(t *testing.T) {
+	a := NewPinyin(Tone3, Normal, " ", false, false)
+	rp := a.ProofreadAnnotated("我(wǒ) 去(qù) 中(zhōng) 国(guǒ) 银(yín) 行(xíng)，很行(hen3 xing2/hang2)！")
+	b, _ := json.Marshal(rp)
+	expected := `{"text":"我去中国银行，很行！","hanzi":8,"syllables":8,"issues":[` +
+		`{"kind":"tone","offset":9,"hanzi":"国","pinyin":"guǒ","expected":["guó"],` +
+		`"message":"\"国\" reads guó, not guǒ"},` +
+		`{"kind":"context","offset":15,"hanzi":"行","pinyin":"xíng","expected":["háng"],"word":"银行",` +
+		`"message":"\"行\" reads háng in \"银行\""}]}`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, b)
+	}
+
+	// 往返
+	hans := "我去中国银行取钱，东西很好。"
+	for _, s := range []string{
+		NewPinyin(Tone3, Both, " ", false, true).Convert(hans),
+		NewPinyin(Tone1, Both, "", true, false).Convert(hans),
+		NewRuby(a).Render(hans),
+	} {
+		if rp := a.ProofreadAnnotated(s); !rp.OK() || rp.Text != hans || rp.Hanzi != 12 {
+			t.Errorf("'%s' expects no issue, got %+v", s, rp)
+		}
+	}
+
+	rp = a.ProofreadAnnotated("中国(zhong1 guo2 ren2)人")
+	if len(rp.Issues) != 1 || rp.Issues[0].Kind != IssueCount || rp.Issues[0].Hanzi != "中国" {
+		t.Errorf("Unexpected %+v", rp.Issues)
+	}
+}
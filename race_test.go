@@ -0,0 +1,8 @@
+//go:build race
+// +build race
+
+package pinyin
+
+func init() {
+	raceEnabled = true
+}
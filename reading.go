@@ -0,0 +1,72 @@
+package pinyin
+
+import (
+	"sort"
+	"strings"
+)
+
+// Reading 汉字的一个读音及其概率
+type Reading struct {
+	Pinyin string  `json:"pinyin"` // 读音 (声调在韵母上)
+	Prob   float64 `json:"prob"`   // 概率
+}
+
+// Readings 返回汉字 r 的全部读音，按概率从高到低排列.
+// The probabilities come from ReadingFreq; for the runes not listed there,
+// the readings keep their PinyinDict order, with halving weights.
+func Readings(r rune) []Reading {
+	r = Unified(r)
+	value, ok := PinyinDict[int(r)]
+	if !ok {
+		return nil
+	}
+	pys := strings.Split(value, ",")
+	freq := ReadingFreq[int(r)]
+	readings := make([]Reading, len(pys))
+	total := 0.0
+	for i, py := range pys {
+		weight := 1.0 / float64(int(1)<<uint(i))
+		if freq != nil {
+			weight = 1
+			if f, ok := freq[py]; ok {
+				weight = float64(f)
+			}
+		}
+		readings[i] = Reading{Pinyin: py, Prob: weight}
+		total += weight
+	}
+	for i := range readings {
+		readings[i].Prob /= total
+	}
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].Prob > readings[j].Prob
+	})
+	return readings
+}
+
+// rankedReadings 把汉字 r 按概率排列的读音添加到 pys，词语读音 phrase (可为空) 优先，
+// 去重后限于概率不低于 PolyphoneMinProb 的读音；PolyphoneTop 见 top
+func (a Pinyin) rankedReadings(pys []string, r rune, phrase string) []string {
+	start := len(pys)
+	if phrase != "" {
+		pys = append(pys, phrase)
+	}
+	for _, rd := range Readings(r) {
+		if rd.Pinyin == phrase {
+			continue
+		}
+		if len(pys) > start && rd.Prob < a.PolyphoneMinProb {
+			break
+		}
+		pys = append(pys, rd.Pinyin)
+	}
+	return pys
+}
+
+// top 返回按拼音风格处理并去重后的读音 pys 的前 PolyphoneTop 个
+func (a Pinyin) top(pys []string) []string {
+	if a.PolyphoneTop > 0 && len(pys) > a.PolyphoneTop {
+		return pys[:a.PolyphoneTop]
+	}
+	return pys
+}
@@ -0,0 +1,95 @@
+package pinyin
+
+// ReadingFreq 常用多音字各个读音的使用频率 (约为百分比)，未列出的读音按 1 计
+var ReadingFreq = map[int]map[string]int{
+	'薄': {"báo": 50, "bó": 45, "bò": 5},
+	'背': {"bèi": 85, "bēi": 15},
+	'便': {"biàn": 85, "pián": 15},
+	'藏': {"cáng": 70, "zàng": 30},
+	'曾': {"céng": 85, "zēng": 15},
+	'参': {"cān": 90, "shēn": 8, "cēn": 2},
+	'差': {"chà": 45, "chā": 30, "chāi": 20, "cī": 5},
+	'场': {"chǎng": 90, "cháng": 10},
+	'长': {"cháng": 60, "zhǎng": 40},
+	'朝': {"cháo": 60, "zhāo": 40},
+	'澄': {"chéng": 85, "dèng": 15},
+	'乘': {"chéng": 95, "shèng": 5},
+	'冲': {"chōng": 85, "chòng": 15},
+	'处': {"chù": 50, "chǔ": 50},
+	'畜': {"chù": 60, "xù": 40},
+	'传': {"chuán": 85, "zhuàn": 15},
+	'大': {"dà": 98, "dài": 2},
+	'单': {"dān": 95, "shàn": 3, "chán": 2},
+	'当': {"dāng": 85, "dàng": 15},
+	'倒': {"dào": 60, "dǎo": 40},
+	'得': {"de": 60, "dé": 35, "děi": 5},
+	'的': {"de": 94, "dí": 4, "dì": 2},
+	'地': {"de": 45, "dì": 55},
+	'调': {"diào": 55, "tiáo": 45},
+	'都': {"dōu": 85, "dū": 15},
+	'度': {"dù": 95, "duó": 5},
+	'恶': {"è": 85, "wù": 10, "ě": 5},
+	'发': {"fā": 90, "fà": 10},
+	'分': {"fēn": 85, "fèn": 15},
+	'缝': {"fèng": 50, "féng": 50},
+	'干': {"gàn": 50, "gān": 50},
+	'给': {"gěi": 90, "jǐ": 10},
+	'更': {"gèng": 75, "gēng": 25},
+	'还': {"hái": 85, "huán": 15},
+	'好': {"hǎo": 90, "hào": 10},
+	'和': {"hé": 92, "hè": 3, "huò": 3, "huó": 1, "hú": 1},
+	'会': {"huì": 97, "kuài": 3},
+	'几': {"jǐ": 85, "jī": 15},
+	'系': {"xì": 90, "jì": 10},
+	'假': {"jiǎ": 70, "jià": 30},
+	'间': {"jiān": 80, "jiàn": 20},
+	'将': {"jiāng": 85, "jiàng": 15},
+	'降': {"jiàng": 90, "xiáng": 10},
+	'角': {"jiǎo": 85, "jué": 15},
+	'教': {"jiào": 70, "jiāo": 30},
+	'结': {"jié": 90, "jiē": 10},
+	'解': {"jiě": 92, "jiè": 4, "xiè": 4},
+	'尽': {"jìn": 70, "jǐn": 30},
+	'禁': {"jìn": 85, "jīn": 15},
+	'卷': {"juǎn": 55, "juàn": 45},
+	'觉': {"jué": 80, "jiào": 20},
+	'看': {"kàn": 95, "kān": 5},
+	'壳': {"ké": 70, "qiào": 30},
+	'空': {"kōng": 75, "kòng": 25},
+	'乐': {"lè": 65, "yuè": 35},
+	'了': {"le": 90, "liǎo": 9, "liào": 1},
+	'量': {"liàng": 70, "liáng": 30},
+	'露': {"lù": 80, "lòu": 20},
+	'率': {"lǜ": 55, "shuài": 45},
+	'没': {"méi": 90, "mò": 10},
+	'闷': {"mèn": 60, "mēn": 40},
+	'模': {"mó": 85, "mú": 15},
+	'难': {"nán": 90, "nàn": 10},
+	'泊': {"bó": 70, "pō": 30},
+	'奇': {"qí": 95, "jī": 5},
+	'强': {"qiáng": 85, "qiǎng": 12, "jiàng": 3},
+	'切': {"qiē": 50, "qiè": 50},
+	'曲': {"qǔ": 50, "qū": 50},
+	'散': {"sàn": 55, "sǎn": 45},
+	'丧': {"sàng": 60, "sāng": 40},
+	'少': {"shǎo": 85, "shào": 15},
+	'省': {"shěng": 85, "xǐng": 15},
+	'数': {"shù": 75, "shǔ": 24, "shuò": 1},
+	'似': {"sì": 90, "shì": 10},
+	'弹': {"tán": 55, "dàn": 45},
+	'为': {"wéi": 55, "wèi": 45},
+	'相': {"xiāng": 80, "xiàng": 20},
+	'鲜': {"xiān": 90, "xiǎn": 10},
+	'兴': {"xīng": 55, "xìng": 45},
+	'行': {"xíng": 60, "háng": 35, "xìng": 3, "hàng": 1, "héng": 1},
+	'血': {"xuè": 70, "xiě": 30},
+	'要': {"yào": 95, "yāo": 5},
+	'应': {"yīng": 55, "yìng": 45},
+	'正': {"zhèng": 97, "zhēng": 3},
+	'只': {"zhǐ": 85, "zhī": 15},
+	'中': {"zhōng": 95, "zhòng": 5},
+	'种': {"zhǒng": 70, "zhòng": 28, "chóng": 2},
+	'重': {"zhòng": 75, "chóng": 25},
+	'转': {"zhuǎn": 75, "zhuàn": 25},
+	'着': {"zhe": 75, "zháo": 12, "zhuó": 10, "zhāo": 3},
+}
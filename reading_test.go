@@ -0,0 +1,75 @@
+package pinyin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReadings(t *testing.T) {
+	rs := Readings('行')
+	if len(rs) != 5 || rs[0].Pinyin != "xíng" || rs[1].Pinyin != "háng" {
+		t.Errorf("unexpected readings %v", rs)
+	}
+	rs = Readings('长')
+	if len(rs) != 2 || rs[0] != (Reading{"cháng", 0.6}) || rs[1] != (Reading{"zhǎng", 0.4}) {
+		t.Errorf("unexpected readings %v", rs)
+	}
+	// 没有频率数据的多音字保持 PinyinDict 的次序
+	rs = Readings('扎')
+	if len(rs) != 4 || rs[0].Pinyin != "zhā" || rs[3].Pinyin != "zā" || rs[0].Prob <= rs[1].Prob {
+		t.Errorf("unexpected readings %v", rs)
+	}
+	for _, r := range "行长扎中" {
+		total := 0.0
+		for _, rd := range Readings(r) {
+			total += rd.Prob
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("%c: the probabilities add up to %f", r, total)
+		}
+	}
+	if Readings('a') != nil {
+		t.Errorf("'a' expects no readings")
+	}
+
+	top := NewPinyin(Tone3, Normal, " ", true, false)
+	top.PolyphoneTop = 2
+	min := NewPinyin(Tone3, Normal, " ", true, false)
+	min.PolyphoneMinProb = 0.11
+	one := NewPinyin(Tone3, Normal, " ", true, false)
+	one.PolyphoneTop = 1
+	testPinyinUpdate(t, []testItem{
+		{"长行", top, "cháng/zhǎng xíng/háng "},
+		{"的行着", min, "de xíng/háng zhe/zháo "},
+		{"银行", top, "yín háng/xíng "},
+		{"银行", one, "yín háng "},
+		{"很行", one, "hěn xíng "},
+	})
+
+	// 按去重后的读音计数
+	normal := NewPinyin(Normal, Normal, " ", true, false)
+	normal.PolyphoneTop = 3
+	first := NewPinyin(Normal, FirstLetter, " ", true, false)
+	first.PolyphoneTop = 2
+	testPinyinUpdate(t, []testItem{
+		{"行", normal, "xing/hang/heng "},
+		{"银行", normal, "yin hang/xing/heng "},
+		{"的长", first, "d c/z "},
+	})
+}
+
+func TestDistinctReadings(t *testing.T) {
+	testData := []testItem{
+		{"的行", NewPinyin(Normal, Normal, " ", true, false), "de/di xing/hang/heng "},
+		{"的行", NewPinyin(Normal, FirstLetter, " ", true, false), "d x/h "},
+		{"中行", NewPinyin(Normal, Initials, " ", true, false), "zh x/h "},
+		{"的行", NewPinyin(Normal, Finals, " ", true, false), "e/i ing/ang/eng "},
+		{"的行", NewPinyin(Tone1, Normal, " ", true, false), "de/di2/di4 xing2/hang2/xing4/hang4/heng2 "},
+	}
+	testPinyinUpdate(t, testData)
+
+	tokens := NewPinyin(Normal, Normal, " ", true, false).Tokens("行")
+	if len(tokens) != 1 || len(tokens[0].Pinyin) != 3 {
+		t.Errorf("unexpected tokens %+v", tokens)
+	}
+}
@@ -0,0 +1,68 @@
+package pinyin
+
+import (
+	"bytes"
+	"html"
+	"strings"
+)
+
+// Ruby 配置 HTML ruby 注音输出
+type Ruby struct {
+	Pinyin
+	PerChar        bool   // 逐字注音（默认：按词组，即每段连续的汉字，注音）
+	PolyphoneClass string // 多音字的 CSS class（默认：不标注）
+	PolyphoneData  bool   // 用 data-readings 属性列出多音字的全部读音
+}
+
+// NewRuby 返回按 a 的拼音风格注音的 `Ruby`
+func NewRuby(a Pinyin) Ruby {
+	return Ruby{Pinyin: a}
+}
+
+// Render 汉字转 HTML ruby 注音.
+// E.g., for input like "中国<人>", the output is
+// <ruby>中国<rp>(</rp><rt>zhōng guó</rt><rp>)</rp></ruby>&lt;<ruby>人<rp>(</rp><rt>rén</rt><rp>)</rp></ruby>&gt;
+func (ru Ruby) Render(s string) string {
+	out := bytes.NewBufferString("")
+	tokens := ru.Tokens(s)
+	for i := 0; i < len(tokens); i++ {
+		if !tokens[i].Han {
+			out.WriteString(html.EscapeString(tokens[i].Text))
+			continue
+		}
+		j := i + 1
+		if !ru.PerChar {
+			for j < len(tokens) && tokens[j].Han {
+				j++
+			}
+		}
+		ru.writeRuby(out, tokens[i:j])
+		i = j - 1
+	}
+	return out.String()
+}
+
+// writeRuby writes the Han tokens out as one ruby element
+func (ru Ruby) writeRuby(out *bytes.Buffer, tokens []Token) {
+	text, rt, readings := "", []string{}, []string{}
+	polyphone := false
+	for _, t := range tokens {
+		text += t.Text
+		rt = append(rt, strings.Join(t.Pinyin, "/"))
+		all := ru.readings([]rune(t.Text)[0])
+		polyphone = polyphone || len(all) > 1
+		readings = append(readings, strings.Join(all, "/"))
+	}
+
+	out.WriteString("<ruby")
+	if polyphone && ru.PolyphoneClass != "" {
+		out.WriteString(` class="` + html.EscapeString(ru.PolyphoneClass) + `"`)
+	}
+	if polyphone && ru.PolyphoneData {
+		out.WriteString(` data-readings="` +
+			html.EscapeString(strings.Join(readings, " ")) + `"`)
+	}
+	out.WriteString(">" + html.EscapeString(text) +
+		"<rp>(</rp><rt>" + html.EscapeString(strings.Join(rt, " ")) +
+		"</rt><rp>)</rp></ruby>")
+}
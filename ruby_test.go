@@ -0,0 +1,31 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestRuby(t *testing.T) {
+	a := NewPinyin(Tone3, Normal, " ", false, false)
+	testData := []struct {
+		ru     Ruby
+		hans   string
+		result string
+	}{
+		{NewRuby(a), "中国<人>",
+			"<ruby>中国<rp>(</rp><rt>zhōng guó</rt><rp>)</rp></ruby>&lt;" +
+				"<ruby>人<rp>(</rp><rt>rén</rt><rp>)</rp></ruby>&gt;"},
+		{Ruby{Pinyin: a, PerChar: true}, "中国",
+			"<ruby>中<rp>(</rp><rt>zhōng</rt><rp>)</rp></ruby>" +
+				"<ruby>国<rp>(</rp><rt>guó</rt><rp>)</rp></ruby>"},
+		{Ruby{Pinyin: a, PerChar: true, PolyphoneClass: "poly", PolyphoneData: true}, "银行&",
+			"<ruby>银<rp>(</rp><rt>yín</rt><rp>)</rp></ruby>" +
+				`<ruby class="poly" data-readings="xíng/háng/xìng/hàng/héng">行<rp>(</rp><rt>háng</rt><rp>)</rp></ruby>&amp;`},
+		{Ruby{Pinyin: a, PolyphoneClass: "poly"}, "a中国",
+			`a<ruby class="poly">中国<rp>(</rp><rt>zhōng guó</rt><rp>)</rp></ruby>`},
+	}
+	for _, tc := range testData {
+		if v := tc.ru.Render(tc.hans); v != tc.result {
+			t.Errorf("'%s' expects '%s', got '%s'", tc.hans, tc.result, v)
+		}
+	}
+}
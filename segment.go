@@ -0,0 +1,252 @@
+package pinyin
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultWordFreq 没有给定词频的词语所用的词频
+const defaultWordFreq = 100
+
+// Segmenter 基于 PhraseDict 的中文分词器.
+// Like jieba, it builds the directed acyclic graph (DAG) of all the dictionary
+// words found in the text, and then uses dynamic programming to find the
+// segmentation of maximum probability, based on the word frequencies.
+// It is safe for concurrent use.
+type Segmenter struct {
+	mu     sync.RWMutex
+	words  map[string]word
+	total  float64 // 全部词频之和
+	maxLen int     // 最长词语的字数
+}
+
+// word 词典中的词语
+type word struct {
+	pinyin string // 读音，以空格分隔各个音节，为空则逐字取读音
+	freq   int    // 词频
+}
+
+// DefaultSegmenter 包含 PhraseDict 中全部词语的分词器
+var DefaultSegmenter = NewSegmenter()
+
+// NewSegmenter 返回包含 PhraseDict 中全部词语的分词器
+func NewSegmenter() *Segmenter {
+	sg := &Segmenter{words: map[string]word{}}
+	for w, py := range PhraseDict {
+		sg.addWord(w, py, defaultWordFreq)
+	}
+	return sg
+}
+
+// AddWord 往分词器中添加词语 w.
+// The pinyin gives the syllables of w separated by spaces, and can be empty
+// to have w read rune by rune; freq is the word frequency, with freq <= 0
+// for the default. It returns an error, without adding w, if the pinyin
+// doesn't have one syllable per rune of w.
+func (sg *Segmenter) AddWord(w, pinyin string, freq int) error {
+	if n := len(strings.Fields(pinyin)); n > 0 && n != utf8.RuneCountInString(w) {
+		return fmt.Errorf("pinyin: %d syllables in %q for the %d runes of %q",
+			n, pinyin, utf8.RuneCountInString(w), w)
+	}
+	if freq <= 0 {
+		freq = defaultWordFreq
+	}
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.addWord(w, pinyin, freq)
+	return nil
+}
+
+func (sg *Segmenter) addWord(w, pinyin string, freq int) {
+	if old, ok := sg.words[w]; ok {
+		sg.total -= float64(old.freq)
+	}
+	sg.words[w] = word{pinyin: pinyin, freq: freq}
+	sg.total += float64(freq)
+	if n := utf8.RuneCountInString(w); n > sg.maxLen {
+		sg.maxLen = n
+	}
+}
+
+// Reading 返回词语 w 的读音，以空格分隔各个音节
+func (sg *Segmenter) Reading(w string) (string, bool) {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	e, ok := sg.words[w]
+	return e.pinyin, ok && e.pinyin != ""
+}
+
+// Cut 把 s 切分为词语.
+// Runs of Han runes are segmented with the dictionary, runs of letters and
+// digits, and of white space, are kept together, and any other runes,
+// e.g., punctuation, stand alone. Joining the result gives back s.
+func (sg *Segmenter) Cut(s string) []string {
+	words := []string{}
+	for len(s) > 0 {
+		r, n := utf8.DecodeRuneInString(s)
+		class := runeClass(r)
+		if class == classOther {
+			words = append(words, s[:n])
+			s = s[n:]
+			continue
+		}
+		for n < len(s) {
+			r, size := utf8.DecodeRuneInString(s[n:])
+			if runeClass(r) != class {
+				break
+			}
+			n += size
+		}
+		if class == classHan {
+			words = append(words, sg.cutHan(s[:n])...)
+		} else {
+			words = append(words, s[:n])
+		}
+		s = s[n:]
+	}
+	return words
+}
+
+// cutHan 以最大概率路径切分汉字串 s
+func (sg *Segmenter) cutHan(s string) []string {
+	words := []string{}
+	sg.segment(s, func(w string) {
+		words = append(words, w)
+	})
+	return words
+}
+
+// segScratch 分词所用的缓冲区，见 segScratchPool
+type segScratch struct {
+	off   []int
+	route []float64
+	next  []int
+}
+
+// 分词的缓冲区，以免每次分词都分配内存
+var segScratchPool = sync.Pool{New: func() interface{} { return new(segScratch) }}
+
+// segment 以最大概率路径切分汉字串 s，依次把各个词语交给 yield
+func (sg *Segmenter) segment(s string, yield func(w string)) {
+	sc := segScratchPool.Get().(*segScratch)
+	defer segScratchPool.Put(sc)
+
+	sg.mu.RLock()
+	// off[i] 为第 i 个字的字节位置
+	off := sc.off[:0]
+	for i := range s {
+		off = append(off, i)
+	}
+	n := len(off)
+	off = append(off, len(s))
+	logTotal := math.Log(sg.total + float64(n))
+	// route[i] 为从第 i 个字起的最大对数概率，next[i] 为其第一个词语的结尾
+	if cap(sc.route) < n+1 {
+		sc.route, sc.next = make([]float64, n+1), make([]int, n+1)
+	}
+	route, next := sc.route[:n+1], sc.next[:n+1]
+	route[n], next[n] = 0, 0
+	for i := n - 1; i >= 0; i-- {
+		route[i], next[i] = math.Inf(-1), i+1
+		for j := i + 1; j <= n && (j == i+1 || j-i <= sg.maxLen); j++ {
+			freq := 1 // 单字
+			if e, ok := sg.words[s[off[i]:off[j]]]; ok {
+				freq = e.freq
+			} else if j > i+1 {
+				continue
+			}
+			if p := math.Log(float64(freq)) - logTotal + route[j]; p > route[i] {
+				route[i], next[i] = p, j
+			}
+		}
+	}
+	sg.mu.RUnlock()
+	sc.off = off
+
+	for i := 0; i < n; i = next[i] {
+		yield(s[off[i]:off[next[i]]])
+	}
+}
+
+// -- 字符类别 runeClass
+const (
+	classOther  = iota // 标点等，各自成词
+	classHan           // 汉字
+	classLetter        // 字母、数字
+	classSpace         // 空白
+)
+
+func runeClass(r rune) int {
+	switch {
+	case unicode.Is(unicode.Han, r):
+		return classHan
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return classLetter
+	case unicode.IsSpace(r):
+		return classSpace
+	}
+	return classOther
+}
+
+// words 按 a 的分词器切分汉字串 s，没有分词器时逐字切分
+func (a Pinyin) words(s string) []string {
+	if a.Segmenter == nil {
+		return strings.Split(s, "")
+	}
+	return a.Segmenter.cutHan(s)
+}
+
+// eachWord 同 words，依次把各个词语交给 yield，不分配内存
+func (a Pinyin) eachWord(s string, yield func(w string)) {
+	if a.Segmenter != nil {
+		a.Segmenter.segment(s, yield)
+		return
+	}
+	for i, r := range s {
+		yield(s[i : i+utf8.RuneLen(r)])
+	}
+}
+
+// phraseReading 返回词语 w 各个字的读音，w 没有词语读音时返回 nil
+func (a Pinyin) phraseReading(w string) []string {
+	if py := a.phraseSyllables(w); py != "" {
+		return strings.Fields(py)
+	}
+	return nil
+}
+
+// phraseSyllables 同 phraseReading，返回以空格分隔的读音，没有时返回 ""
+func (a Pinyin) phraseSyllables(w string) string {
+	if a.Segmenter == nil || utf8.RuneCountInString(w) < 2 {
+		return ""
+	}
+	py, ok := a.Segmenter.Reading(w)
+	if !ok || countFields(py) != utf8.RuneCountInString(w) {
+		return ""
+	}
+	return py
+}
+
+// countFields 同 len(strings.Fields(s))，不分配内存
+func countFields(s string) int {
+	n := 0
+	for s != "" {
+		_, s = nextField(s)
+		n++
+	}
+	return n
+}
+
+// nextField 返回 s 中以空白分隔的第一个字段及其后的文本，没有字段时都为 ""
+func nextField(s string) (field, rest string) {
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	i := strings.IndexFunc(s, unicode.IsSpace)
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+}
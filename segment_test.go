@@ -0,0 +1,69 @@
+package pinyin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCut(t *testing.T) {
+	sg := NewSegmenter()
+	testData := []struct {
+		s, result string
+	}{
+		{"中国人", "中国人"},
+		{"我们去中国银行。", "我们/去/中国/银行/。"},
+		{"用Go写 hello world", "用/Go/写/ /hello/ /world"},
+		{"1996—9月30日", "1996/—/9/月/30/日"},
+		{"", ""},
+	}
+	for _, tc := range testData {
+		if v := strings.Join(sg.Cut(tc.s), "/"); v != tc.result {
+			t.Errorf("'%s' expects '%s', got '%s'", tc.s, tc.result, v)
+		}
+	}
+
+	sg.AddWord("行长", "háng zhǎng", 0)
+	sg.AddWord("银行行长", "", 1000)
+	if v := strings.Join(sg.Cut("银行行长"), "/"); v != "银行行长" {
+		t.Errorf(`Expected "银行行长", got "%s"`, v)
+	}
+	if _, ok := sg.Reading("银行行长"); ok {
+		t.Errorf(`"银行行长" expects no reading`)
+	}
+	if v, _ := sg.Reading("行长"); v != "háng zhǎng" {
+		t.Errorf(`Expected "háng zhǎng", got "%s"`, v)
+	}
+}
+
+func TestSegmenter(t *testing.T) {
+	sg := NewSegmenter()
+	sg.AddWord("行长", "háng zhǎng", 0)
+	a := NewPinyin(Tone3, Normal, " ", false, false)
+	a.Segmenter = sg
+	b := NewPinyin(Tone3, Normal, " ", false, false)
+	b.Segmenter = nil
+	p := NewPinyin(Tone3, Normal, " ", true, false)
+	testData := []testItem{
+		{"银行行长", a, "yín háng háng zhǎng "},
+		{"银行", b, "yín xíng "},
+		{"长大了，重新音乐", a, "zhǎng dà le ，chóng xīn yīn yuè "},
+		{"银行", p, "yín háng/xíng/xìng/hàng/héng "},
+	}
+	testPinyinUpdate(t, testData)
+
+	// 音节数与字数不符
+	if err := sg.AddWord("银行家", "yín háng", 0); err == nil {
+		t.Errorf(`"银行家" with 2 syllables expects an error`)
+	}
+	if _, ok := sg.Reading("银行家"); ok {
+		t.Errorf(`"银行家" expects not added`)
+	}
+	// 不符的读音被忽略，逐字取读音
+	sg.addWord("银行家", "yín háng", 1000)
+	o := a
+	o.Orthographic = true
+	testPinyinUpdate(t, []testItem{
+		{"银行家", a, "yín xíng jiā "},
+		{"银行家", o, "Yínxíngjiā"},
+	})
+}
@@ -0,0 +1,266 @@
+package pinyin
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parts 输出音节的哪些部分，可组合使用
+type Parts int
+
+// -- 音节的部分 Parts
+const (
+	PartSyllable    Parts = 1 << iota // 整个音节（默认）。如： zhong guo
+	PartFirstLetter                   // 首字母。如： z g
+	PartInitial                       // 声母。如： zh g
+	PartFinal                         // 韵母。如： ong uo
+	PartHanzi                         // 汉字，与拼音双显。如： 中(zhong) 国(guo)
+)
+
+// Umlaut ü 的拼写
+type Umlaut int
+
+// -- ü 的拼写 Umlaut
+const (
+	UmlautAuto  Umlaut = iota // 带声调符号时为 ü，否则为 v（默认）。如： lǜ, lv4
+	UmlautU                   // ü。如： lü4
+	UmlautV                   // v，带声调符号时仍为 ǖ ǘ ǚ ǜ。如： lv4
+	UmlautColon               // u:，如 CC-CEDICT，带声调符号时仍为 ǖ ǘ ǚ ǜ。如： lu:4
+	UmlautYU                  // yu，如护照。如： lyu4, lyù
+)
+
+// Form 声调符号等附加符号的编码
+type Form int
+
+// -- 附加符号的编码 Form
+const (
+	FormNFC Form = iota // 尽量用预组合字符 (NFC)（默认）。如： ǒ (U+01D2)
+	FormNFD             // 基本字母 + 组合用附加符号 (NFD)。如： o + U+030C
+)
+
+// Case 拼音的大小写
+type Case int
+
+// -- 大小写 Case
+const (
+	CaseLower Case = iota // 小写（默认）
+	CaseTitle             // 首字母大写
+	CaseUpper             // 全部大写
+)
+
+// Style 配置拼音风格：声调风格、输出的部分、ü 的拼写以及大小写，可自由组合.
+// E.g., the first letters together with the Hanzi,
+//
+//	Style{Parts: PartHanzi | PartFirstLetter}
+//
+// or the zero consonant finals with the initials shown separately,
+//
+//	Style{Tone: Tone1, Parts: PartInitial | PartFinal, NormalizeYW: true, Joiner: "-"}
+//
+// NewStyle maps the tone and truncate constants onto it.
+type Style struct {
+	Tone        Tone   // 声调风格：Normal, Tone1, Tone2 或 Tone3（默认：Normal）
+	Parts       Parts  // 输出的部分（默认：PartSyllable）
+	NormalizeYW bool   // y, w 不作为声母，韵母按零声母规则还原。如： yan -> ian, wu -> u
+	Joiner      string // 输出多个部分时，各部分之间的分隔符
+	Umlaut      Umlaut // ü 的拼写（默认：UmlautAuto）
+	Form        Form   // 声调符号及 ü, ê 的编码（默认：FormNFC）
+	Case        Case   // 大小写（默认：CaseLower）
+}
+
+// NewStyle 返回声调风格 tone (Normal, Tone1, Tone2 或 Tone3) 与
+// 部分返回 truncate (Normal, FirstLetter, Initials, ZeroConsonant, Finals 或 Both)
+// 对应的 Style
+func NewStyle(tone, truncate int) Style {
+	st := Style{Tone: Tone(tone), Parts: PartSyllable}
+	switch truncate {
+	case FirstLetter:
+		st.Parts = PartFirstLetter
+	case Initials:
+		st.Parts = PartInitial
+	case ZeroConsonant:
+		st.Parts, st.NormalizeYW = PartFinal, true
+	case Finals:
+		st.Parts = PartFinal
+	case Both:
+		st.Parts = PartHanzi | PartSyllable
+	}
+	return st
+}
+
+// Valid tells whether st is a valid style
+func (st Style) Valid() error {
+	switch {
+	case !st.Tone.Valid():
+		return fmt.Errorf("pinyin: invalid tone %d", st.Tone)
+	case st.Parts < 0 || st.Parts >= PartHanzi<<1:
+		return fmt.Errorf("pinyin: invalid parts %d", st.Parts)
+	case st.Umlaut < UmlautAuto || st.Umlaut > UmlautYU:
+		return fmt.Errorf("pinyin: invalid umlaut %d", st.Umlaut)
+	case st.Form < FormNFC || st.Form > FormNFD:
+		return fmt.Errorf("pinyin: invalid form %d", st.Form)
+	case st.Case < CaseLower || st.Case > CaseUpper:
+		return fmt.Errorf("pinyin: invalid case %d", st.Case)
+	}
+	return nil
+}
+
+// hanzi tells whether st outputs the Hanzi together with the pinyin
+func (st Style) hanzi() bool {
+	return st.Parts&PartHanzi != 0
+}
+
+// Render 按拼音风格输出音节 sy 的各个部分 (PartHanzi 除外)
+func (st Style) Render(sy Syllable) string {
+	parts := st.Parts &^ PartHanzi
+	if parts == 0 {
+		parts = PartSyllable
+	}
+	out := []string{}
+	if parts&PartSyllable != 0 {
+		out = append(out, st.toned(sy.plain, sy.tone))
+	}
+	if parts&PartFirstLetter != 0 {
+		_, size := utf8.DecodeRuneInString(sy.plain)
+		out = append(out, st.toned(sy.plain[:size], 0))
+	}
+	if i := st.initial(sy); parts&PartInitial != 0 && i != "" {
+		out = append(out, i)
+	}
+	if parts&PartFinal != 0 {
+		out = append(out, st.toned(st.final(sy), sy.tone))
+	}
+	py := strings.Join(out, st.Joiner)
+	if st.Form == FormNFD {
+		py = decompose(py)
+	}
+	switch {
+	case py == "":
+	case st.Case == CaseTitle:
+		r, size := utf8.DecodeRuneInString(py)
+		py = string(unicode.ToUpper(r)) + py[size:]
+	case st.Case == CaseUpper:
+		py = strings.ToUpper(py)
+	}
+	return py
+}
+
+// initial 声母，y, w 作为声母，除非 NormalizeYW
+func (st Style) initial(sy Syllable) string {
+	if st.NormalizeYW || nasalSyllables[sy.plain] {
+		return sy.Initial()
+	}
+	for _, v := range initialArrayYW {
+		if strings.HasPrefix(sy.plain, v) {
+			return v
+		}
+	}
+	return ""
+}
+
+// final 韵母，y, w 作为声母，除非 NormalizeYW
+func (st Style) final(sy Syllable) string {
+	if st.NormalizeYW || nasalSyllables[sy.plain] {
+		return sy.Final()
+	}
+	final := sy.plain[len(st.initial(sy)):]
+	if strings.ContainsRune("jqxy", rune(sy.plain[0])) && strings.HasPrefix(final, "u") {
+		// yu -> v
+		final = "v" + final[1:]
+	}
+	return final
+}
+
+// umlaut 返回 ü 的拼写
+func (st Style) umlaut() string {
+	switch {
+	case st.Umlaut == UmlautU, st.Umlaut == UmlautAuto && st.Tone == Tone3:
+		return "ü"
+	case st.Umlaut == UmlautColon:
+		return "u:"
+	case st.Umlaut == UmlautYU:
+		return "yu"
+	}
+	return "v"
+}
+
+// toned 按声调风格给不带声调的拼写 plain (ü 写作 v) 加上声调 tone
+func (st Style) toned(plain string, tone int) string {
+	umlaut := st.umlaut()
+	if tone == 0 || st.Tone == Normal {
+		return strings.Replace(plain, "v", umlaut, -1)
+	}
+	i := toneIndex(plain)
+	if i < 0 {
+		return strings.Replace(plain, "v", umlaut, -1)
+	}
+	r, size := utf8.DecodeRuneInString(plain[i:])
+	head := strings.Replace(plain[:i], "v", umlaut, -1)
+	tail := strings.Replace(plain[i+size:], "v", umlaut, -1)
+	vowel := strings.Replace(string(r), "v", umlaut, -1)
+	switch st.Tone {
+	case Tone1:
+		return head + vowel + tail + fmt.Sprint(tone)
+	case Tone2:
+		return head + vowel + fmt.Sprint(tone) + tail
+	}
+	if r == 'v' && st.Umlaut == UmlautYU {
+		// 标在 yu 的 u 上
+		return head + "y" + toneMarks[fmt.Sprintf("u%d", tone)] + tail
+	}
+	mark, ok := toneMarks[fmt.Sprintf("%c%d", r, tone)]
+	if !ok {
+		mark = vowel + string(combiningMarks[tone])
+	}
+	return head + mark + tail
+}
+
+// toneIndex 返回 plain 中标调的位置：a, e 优先，ou 标在 o 上，否则标在最后一个元音上
+func toneIndex(plain string) int {
+	i := strings.IndexAny(plain, "aeê")
+	if i < 0 {
+		i = strings.Index(plain, "ou")
+	}
+	if i < 0 {
+		i = strings.LastIndexAny(plain, "iouv")
+	}
+	if i < 0 {
+		// 鼻音 m, n
+		i = strings.IndexAny(plain, "mn")
+	}
+	return i
+}
+
+// 附加符号的分解 (NFD)：预组合字符 -> 基本字母 + 组合用附加符号
+var decomposition = func() map[rune]string {
+	m := map[rune]string{
+		'ü': "u\u0308", 'ê': "e\u0302", 'ế': "e\u0302\u0301", 'ề': "e\u0302\u0300",
+	}
+	for symbol, mark := range toneMarks {
+		r, _ := utf8.DecodeRuneInString(mark)
+		if _, ok := m[r]; ok || len(symbol) < 2 {
+			continue
+		}
+		base := symbol[:1]
+		if base == "v" {
+			base = "u\u0308"
+		}
+		m[r] = base + string(combiningMarks[symbol[1]-'0'])
+	}
+	return m
+}()
+
+// decompose 把 s 中的预组合字符分解为基本字母 + 组合用附加符号
+func decompose(s string) string {
+	out := ""
+	for _, r := range s {
+		if d, ok := decomposition[r]; ok {
+			out += d
+		} else {
+			out += string(r)
+		}
+	}
+	return out
+}
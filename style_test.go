@@ -0,0 +1,96 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestStyle(t *testing.T) {
+	testData := []struct {
+		style    Style
+		hans     string
+		expected string
+	}{
+		{Style{}, "中国人", "zhong guo ren "},
+		{Style{Parts: PartHanzi | PartFirstLetter}, "中国", "中(z) 国(g) "},
+		{Style{Parts: PartHanzi}, "中国", "中(zhong) 国(guo) "},
+		{Style{Tone: Tone1, Parts: PartInitial | PartFinal, NormalizeYW: true, Joiner: "-"},
+			"中元王", "zh-ong1 van2 uang2 "},
+		{Style{Tone: Tone2, Parts: PartInitial | PartFinal, Joiner: "'"},
+			"中元王", "zh'o1ng y'va2n w'a2ng "},
+		{Style{Tone: Tone3, Parts: PartSyllable | PartFinal, Joiner: "/"}, "家", "jiā/iā "},
+		{Style{Case: CaseUpper}, "中国", "ZHONG GUO "},
+		{Style{Tone: Tone3, Case: CaseTitle}, "绿女", "Lǜ Nǚ "},
+		{Style{Tone: Tone1, Umlaut: UmlautU}, "绿女", "lü4 nü3 "},
+		{Style{Tone: Tone3, Umlaut: UmlautV}, "绿女", "lǜ nǚ "},
+		{Style{Umlaut: UmlautV}, "绿女", "lv nv "},
+		{Style{Parts: PartFinal, Umlaut: UmlautU}, "军", "ün "},
+		{Style{Tone: Tone1, Umlaut: UmlautColon}, "绿女", "lu:4 nu:3 "},
+		{Style{Tone: Tone2, Umlaut: UmlautColon}, "略", "lu:e4 "},
+		{Style{Tone: Tone3, Umlaut: UmlautColon}, "绿", "lǜ "},
+		{Style{Umlaut: UmlautYU}, "绿女", "lyu nyu "},
+		{Style{Tone: Tone3, Umlaut: UmlautYU}, "绿女略", "lyù nyǔ lyuè "},
+		{Style{Tone: Tone2, Umlaut: UmlautYU}, "绿女略", "lyu4 nyu3 lyue4 "},
+		{Style{Parts: PartFinal, NormalizeYW: true, Umlaut: UmlautYU}, "元军", "yuan yun "},
+		{Style{Tone: Tone3, Form: FormNFD}, "我绿", "wo\u030C lu\u0308\u0300 "},
+		{Style{Tone: Tone3, Form: FormNFD, Case: CaseTitle}, "驴", "Lu\u0308\u0301 "},
+		{Style{Form: FormNFD, Umlaut: UmlautU}, "女", "nu\u0308 "},
+		{NewStyle(Tone2, ZeroConsonant), "呀万军", "ia ua4n v1n "},
+		{NewStyle(Tone3, Finals), "额", "é "},
+		{NewStyle(Normal, FirstLetter), "额", "e "},
+	}
+	for _, tc := range testData {
+		a, err := New(WithStyle(tc.style))
+		if err != nil {
+			t.Errorf("%+v: %v", tc.style, err)
+			continue
+		}
+		if v := a.Convert(tc.hans); v != tc.expected {
+			t.Errorf("%+v expects '%s', got '%s'", tc.style, tc.expected, v)
+		}
+	}
+
+	sy, _ := ParseSyllable("ê2")
+	if v := (Style{Tone: Tone3, Form: FormNFD}).Render(sy); v != "e\u0302\u0301" {
+		t.Errorf("Expected %+q, got %+q", "e\u0302\u0301", v)
+	}
+
+	// ü 的拼写同样用于词语及多音字
+	a, _ := New(WithStyle(Style{Tone: Tone1, Umlaut: UmlautColon}), WithPolyphone())
+	if v := a.Convert("效率绿"); v != "xiao4 lu:4/shuai4/lu:e lu:4/lu4 " {
+		t.Errorf("Expected 'xiao4 lu:4/shuai4/lu:e lu:4/lu4 ', got '%s'", v)
+	}
+
+	// 构造后修改的风格同样生效
+	a = NewPinyin(Normal, Normal, " ", false, false)
+	a.Tone, a.Case = Tone3, CaseUpper
+	o := a
+	o.Orthographic = true
+	f := NewPinyin(Normal, Normal, " ", false, false)
+	f.Parts = PartFinal
+	testPinyinUpdate(t, []testItem{
+		{"中国", a, "ZHŌNG GUÓ "},
+		{"中国", o, "ZHŌNGGUÓ"},
+		{"中国", f, "ong uo "},
+	})
+
+	for _, st := range []Style{{Tone: 4}, {Parts: PartHanzi << 1}, {Umlaut: UmlautYU + 1}, {Form: FormNFD + 1}, {Case: -1}} {
+		if _, err := New(WithStyle(st)); err == nil {
+			t.Errorf("%+v expects an error", st)
+		}
+	}
+}
+
+func TestShaper(t *testing.T) {
+	// the Shaper filters give the same results as the converters
+	for _, tr := range []int{Normal, FirstLetter, Initials, ZeroConsonant, Finals} {
+		for _, tone := range []int{Normal, Tone1, Tone2, Tone3} {
+			a := NewPinyin(tone, tr, "", false, false)
+			sp := NewShaper().ApplyTruncate(a).ApplyToneShaping(a)
+			for _, py := range []string{"zhōng", "yuán", "jūn", "lǜ", "wàn", "ér", "de"} {
+				if v, expected := sp.Process(py), a.shapeOne(py); v != expected {
+					t.Errorf("%d, %d: '%s' expects '%s', got '%s'", tone, tr, py, expected, v)
+				}
+			}
+		}
+	}
+}
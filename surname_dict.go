@@ -0,0 +1,109 @@
+package pinyin
+
+// SurnameDict 姓氏读音表：复姓，以及作姓氏时读音与 PinyinDict 首个读音不同的单姓
+var SurnameDict = map[string]string{
+	// 单姓
+	"卜": "bǔ",
+	"长": "cháng",
+	"重": "chóng",
+	"种": "chóng",
+	"都": "dū",
+	"宓": "fú",
+	"盖": "gě",
+	"句": "gōu",
+	"过": "guō",
+	"黑": "hè",
+	"华": "huà",
+	"纪": "jǐ",
+	"阚": "kàn",
+	"秘": "bì",
+	"缪": "miào",
+	"那": "nā",
+	"能": "nài",
+	"佴": "nài",
+	"粘": "nián",
+	"乜": "niè",
+	"宁": "nìng",
+	"区": "ōu",
+	"朴": "piáo",
+	"繁": "pó",
+	"覃": "qín",
+	"仇": "qiú",
+	"任": "rén",
+	"单": "shàn",
+	"召": "shào",
+	"折": "shé",
+	"隗": "wěi",
+	"相": "xiàng",
+	"解": "xiè",
+	"郇": "xún",
+	"燕": "yān",
+	"於": "yū",
+	"乐": "yuè",
+	"员": "yùn",
+	"藏": "zàng",
+	"曾": "zēng",
+	"查": "zhā",
+	"祭": "zhài",
+	"翟": "zhái",
+
+	// 复姓
+	"百里": "bǎi lǐ",
+	"单于": "chán yú",
+	"第五": "dì wǔ",
+	"东方": "dōng fāng",
+	"东郭": "dōng guō",
+	"东门": "dōng mén",
+	"独孤": "dú gū",
+	"端木": "duān mù",
+	"段干": "duàn gān",
+	"公良": "gōng liáng",
+	"公孙": "gōng sūn",
+	"公西": "gōng xī",
+	"公羊": "gōng yáng",
+	"公冶": "gōng yě",
+	"谷梁": "gǔ liáng",
+	"赫连": "hè lián",
+	"呼延": "hū yán",
+	"皇甫": "huáng fǔ",
+	"梁丘": "liáng qiū",
+	"令狐": "líng hú",
+	"闾丘": "lǘ qiū",
+	"慕容": "mù róng",
+	"万俟": "mò qí",
+	"南宫": "nán gōng",
+	"欧阳": "ōu yáng",
+	"濮阳": "pú yáng",
+	"漆雕": "qī diāo",
+	"亓官": "qí guān",
+	"壤驷": "rǎng sì",
+	"上官": "shàng guān",
+	"申屠": "shēn tú",
+	"司空": "sī kōng",
+	"司寇": "sī kòu",
+	"司马": "sī mǎ",
+	"司徒": "sī tú",
+	"太史": "tài shǐ",
+	"澹台": "tán tái",
+	"拓跋": "tuò bá",
+	"微生": "wēi shēng",
+	"闻人": "wén rén",
+	"巫马": "wū mǎ",
+	"西门": "xī mén",
+	"夏侯": "xià hóu",
+	"鲜于": "xiān yú",
+	"轩辕": "xuān yuán",
+	"羊舌": "yáng shé",
+	"宇文": "yǔ wén",
+	"尉迟": "yù chí",
+	"乐正": "yuè zhèng",
+	"宰父": "zǎi fǔ",
+	"长孙": "zhǎng sūn",
+	"钟离": "zhōng lí",
+	"仲孙": "zhòng sūn",
+	"诸葛": "zhū gě",
+	"颛孙": "zhuān sūn",
+	"子车": "zǐ jū",
+	"宗政": "zōng zhèng",
+	"左丘": "zuǒ qiū",
+}
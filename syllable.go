@@ -0,0 +1,190 @@
+package pinyin
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Syllable 拼音音节，可分解为声母、介音、韵母及声调.
+// The zero value is not a valid syllable, use ParseSyllable.
+type Syllable struct {
+	plain string // 不带声调的拼写，ü 写作 v，如 zhong, yuan, lv
+	tone  int    // 声调 1-4，轻声为 0
+}
+
+// 介音及韵腹韵尾：韵母 -> {介音, 韵腹韵尾}，iu, ui, un 还原为 iou, uei, uen
+var finalParts = map[string][2]string{
+	"ia": {"i", "a"}, "ie": {"i", "e"}, "iao": {"i", "ao"}, "iu": {"i", "ou"}, "iou": {"i", "ou"},
+	"ian": {"i", "an"}, "iang": {"i", "ang"}, "iong": {"i", "ong"},
+	"ua": {"u", "a"}, "uo": {"u", "o"}, "uai": {"u", "ai"}, "ui": {"u", "ei"},
+	"uan": {"u", "an"}, "un": {"u", "en"}, "uang": {"u", "ang"}, "ueng": {"u", "eng"},
+	"uei": {"u", "ei"}, "uen": {"u", "en"},
+	"ve": {"v", "e"}, "van": {"v", "an"},
+}
+
+// 带音标字符：不带声调的字母 + 声调 -> 带音标字符，与 phoneticSymbol 相反
+var toneMarks = func() map[string]string {
+	m := map[string]string{"ê2": "ế", "ê4": "ề"}
+	for mark, symbol := range phoneticSymbol {
+		m[symbol] = mark
+	}
+	return m
+}()
+
+// 组合用声调符号：声调 -> 符号，用于没有带音标字符的 ê, m
+var combiningMarks = []rune{0, '\u0304', '\u0301', '\u030C', '\u0300'}
+
+// 鼻音音节，没有声母
+var nasalSyllables = map[string]bool{"m": true, "n": true, "ng": true, "hm": true, "hng": true}
+
+var (
+	plainSyllablesOnce sync.Once
+	plainSyllables     map[string]bool // PinyinDict 及 PhraseDict 中的全部音节，不带声调
+)
+
+// ParseSyllable 解析任一声调风格的拼音音节，如 zhōng, zho1ng, zhong1 或 zhong.
+// The ü is accepted as ü, v, u: or yu (lyu, nyu), the neutral tone as no tone or 5,
+// the tone marks either precomposed (NFC) or combining (NFD), and the
+// syllable must be one of the syllables found in PinyinDict, regardless of tone.
+func ParseSyllable(s string) (Syllable, error) {
+	sy, ok := parseSyllable(s)
+	if !ok || !knownSyllable(sy.plain) {
+		return Syllable{}, fmt.Errorf("pinyin: invalid syllable %q", s)
+	}
+	return sy, nil
+}
+
+// 音节拼写最长的字节数，如 zhuang4, zhua\u0304ng 或 lu\u0308\u0300e
+const maxSyllableLen = 16
+
+// parseSyllable 解析拼音音节 s 的拼写及声调，不检查是否为已知的音节
+func parseSyllable(s string) (Syllable, bool) {
+	sy := Syllable{}
+	if len(s) > maxSyllableLen {
+		return sy, false
+	}
+	tones := 0
+	for _, r := range strings.Replace(strings.ToLower(s), "u:", "v", -1) {
+		if tone, ok := combiningTone(r); ok {
+			sy.tone = tone
+			tones++
+			continue
+		}
+		switch symbol, ok := phoneticSymbol[string(r)]; {
+		case r == '\u0308' && strings.HasSuffix(sy.plain, "u"):
+			// 组合用分音符：u + U+0308 -> ü
+			sy.plain = sy.plain[:len(sy.plain)-1] + "v"
+		case r == '\u0302' && strings.HasSuffix(sy.plain, "e"):
+			// 组合用扬抑符：e + U+0302 -> ê
+			sy.plain = sy.plain[:len(sy.plain)-1] + "ê"
+		case ok:
+			sy.plain += symbol[:1]
+			if len(symbol) > 1 {
+				sy.tone = int(symbol[1] - '0')
+				tones++
+			}
+		case r >= '1' && r <= '5':
+			sy.tone = int(r-'0') % 5
+			tones++
+		case r >= 'a' && r <= 'z' || r == 'ê':
+			sy.plain += string(r)
+		case r == 'ế' || r == 'ề':
+			sy.plain += "ê"
+			sy.tone = map[rune]int{'ế': 2, 'ề': 4}[r]
+			tones++
+		default:
+			return Syllable{}, false
+		}
+	}
+	if p := sy.plain; strings.HasPrefix(p, "lyu") || strings.HasPrefix(p, "nyu") {
+		// lyu -> lv
+		sy.plain = p[:1] + "v" + p[3:]
+	}
+	if p := sy.plain; len(p) >= 2 && strings.ContainsRune("jqxy", rune(p[0])) && p[1] == 'v' {
+		// jv -> ju
+		sy.plain = p[:1] + "u" + p[2:]
+	}
+	return sy, tones <= 1 && sy.plain != ""
+}
+
+// knownSyllable tells whether plain is a syllable of PinyinDict or PhraseDict
+func knownSyllable(plain string) bool {
+	plainSyllablesOnce.Do(func() {
+		plainSyllables = map[string]bool{}
+		for _, py := range dictSyllables() {
+			if sy, ok := parseSyllable(py); ok {
+				plainSyllables[sy.plain] = true
+			}
+		}
+	})
+	return plainSyllables[plain]
+}
+
+// combiningTone 返回组合用声调符号 r 的声调
+func combiningTone(r rune) (int, bool) {
+	for tone, mark := range combiningMarks {
+		if r == mark && tone > 0 {
+			return tone, true
+		}
+	}
+	return 0, false
+}
+
+// Initial 声母，如 zh；零声母 (包括 y, w) 为 ""
+func (sy Syllable) Initial() string {
+	if nasalSyllables[sy.plain] {
+		return ""
+	}
+	for _, v := range initialArray {
+		if strings.HasPrefix(sy.plain, v) {
+			return v
+		}
+	}
+	return ""
+}
+
+// Final 韵母，按零声母规则还原 y, w 的写法，ü 写作 v，如 zhong -> ong, yuan -> van, wu -> u
+func (sy Syllable) Final() string {
+	initial := sy.Initial()
+	final := sy.plain[len(initial):]
+	switch {
+	case nasalSyllables[sy.plain]:
+		// 鼻音
+		return sy.plain
+	case initial == "":
+		return handleYW(final)
+	case strings.ContainsRune("jqx", rune(initial[0])) && final != "" && final[0] == 'u':
+		// ju -> jv
+		return "v" + final[1:]
+	}
+	return final
+}
+
+// Medial 介音 i, u 或 v (ü)，没有介音时为 ""
+func (sy Syllable) Medial() string {
+	return finalParts[sy.Final()][0]
+}
+
+// Rime 韵腹及韵尾，即去掉介音的韵母，并还原 iu, ui, un 为 iou, uei, uen 的 ou, ei, en
+func (sy Syllable) Rime() string {
+	if parts, ok := finalParts[sy.Final()]; ok {
+		return parts[1]
+	}
+	return sy.Final()
+}
+
+// Tone 声调 1-4，轻声为 0
+func (sy Syllable) Tone() int {
+	return sy.tone
+}
+
+// String 声调在韵母上的拼写，如 zhōng
+func (sy Syllable) String() string {
+	return Style{Tone: Tone3}.toned(sy.plain, sy.tone)
+}
+
+// Render 按拼音风格输出，同 NewPinyin(tone, truncate, ...) 转换的结果
+func (sy Syllable) Render(tone Tone, truncate Truncate) string {
+	return NewStyle(int(tone), int(truncate)).Render(sy)
+}
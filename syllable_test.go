@@ -0,0 +1,100 @@
+package pinyin
+
+import (
+	"testing"
+)
+
+func TestParseSyllable(t *testing.T) {
+	for _, s := range []string{"zhōng", "zho1ng", "zhong1", "Zhōng"} {
+		sy, err := ParseSyllable(s)
+		if err != nil || sy.String() != "zhōng" || sy.Tone() != 1 {
+			t.Errorf("'%s' expects 'zhōng', got '%s' (%v)", s, sy, err)
+		}
+	}
+	for _, tc := range [][2]string{
+		{"lǜ", "lǜ"}, {"lv4", "lǜ"}, {"lu:4", "lǜ"}, {"lyu4", "lǜ"}, {"nyue4", "nüè"}, {"lü4", "lǜ"}, {"nüe4", "nüè"},
+		{"jv1", "jū"}, {"de", "de"}, {"de5", "de"}, {"liu2", "liú"}, {"gui4", "guì"},
+		{"ê2", "ế"}, {"lu\u0308\u0300", "lǜ"}, {"e\u0302\u0301", "ế"}, {"ê\u0304", "ê̄"}, {"LU\u0308\u0300", "lǜ"}, {"m2", "ḿ"}, {"hng", "hng"}, {"er2", "ér"},
+		{"LU:4", "lǜ"}, {"Lu:4", "lǜ"}, {"NU:E4", "nüè"}, {"LYU4", "lǜ"}, {"Nyu3", "nǚ"}, {"LV4", "lǜ"},
+	} {
+		if sy, err := ParseSyllable(tc[0]); err != nil || sy.String() != tc[1] {
+			t.Errorf("'%s' expects '%s', got '%s' (%v)", tc[0], tc[1], sy, err)
+		}
+	}
+	for _, s := range []string{"", "zhongg", "zhōng1", "abc", "中", "zh ong"} {
+		if _, err := ParseSyllable(s); err == nil {
+			t.Errorf("'%s' expects an error", s)
+		}
+	}
+	// 词典中全部音节，NFC 及 NFD
+	for _, py := range dictSyllables() {
+		if sy, err := ParseSyllable(py); err != nil || sy.String() != py {
+			t.Errorf("'%s' expects '%s', got '%s' (%v)", py, py, sy, err)
+		}
+		if sy, err := ParseSyllable(decompose(py)); err != nil || sy.String() != py {
+			t.Errorf("'%+q' expects '%s', got '%s' (%v)", decompose(py), py, sy, err)
+		}
+	}
+}
+
+func TestSyllableParts(t *testing.T) {
+	// 音节：声母，韵母，介音，韵腹韵尾
+	testData := [][5]string{
+		{"zhōng", "zh", "ong", "", "ong"},
+		{"xióng", "x", "iong", "i", "ong"},
+		{"yuán", "", "van", "v", "an"},
+		{"jué", "j", "ve", "v", "e"},
+		{"qù", "q", "v", "", "v"},
+		{"lǜ", "l", "v", "", "v"},
+		{"yī", "", "i", "", "i"},
+		{"yā", "", "ia", "i", "a"},
+		{"wǔ", "", "u", "", "u"},
+		{"wàn", "", "uan", "u", "an"},
+		{"wēng", "", "ueng", "u", "eng"},
+		{"liú", "l", "iu", "i", "ou"},
+		{"guì", "g", "ui", "u", "ei"},
+		{"lún", "l", "un", "u", "en"},
+		{"yǒu", "", "iou", "i", "ou"},
+		{"wèi", "", "uei", "u", "ei"},
+		{"ér", "", "er", "", "er"},
+		{"ńg", "", "ng", "", "ng"},
+		{"hm", "", "hm", "", "hm"},
+	}
+	for _, tc := range testData {
+		sy, err := ParseSyllable(tc[0])
+		if err != nil {
+			t.Errorf("'%s': %v", tc[0], err)
+			continue
+		}
+		if v := [5]string{tc[0], sy.Initial(), sy.Final(), sy.Medial(), sy.Rime()}; v != tc {
+			t.Errorf("'%s' expects %q, got %q", tc[0], tc, v)
+		}
+	}
+}
+
+func TestSyllableRender(t *testing.T) {
+	sy, _ := ParseSyllable("yuan2")
+	for _, tc := range []struct {
+		tone     Tone
+		truncate Truncate
+		expected string
+	}{
+		{Normal, Normal, "yuan"},
+		{Tone1, Normal, "yuan2"},
+		{Tone2, Normal, "yua2n"},
+		{Tone3, Normal, "yuán"},
+		{Tone3, FirstLetter, "y"},
+		{Tone3, Initials, "y"},
+		{Tone2, ZeroConsonant, "va2n"},
+		{Tone3, Finals, "üán"},
+		{Tone3, Both, "yuán"},
+	} {
+		if v := sy.Render(tc.tone, tc.truncate); v != tc.expected {
+			t.Errorf("%d, %d expects '%s', got '%s'", tc.tone, tc.truncate, tc.expected, v)
+		}
+		a := NewPinyin(int(tc.tone), int(tc.truncate), "", false, false)
+		if v := a.Convert("元"); tc.truncate != Both && v != tc.expected {
+			t.Errorf("%d, %d expects '%s' as Convert, got '%s'", tc.tone, tc.truncate, tc.expected, v)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package pinyin
+
+import (
+	"strings"
+	"sync"
+)
+
+// styleTable 一种拼音风格的音节表，首次使用时构造
+type styleTable struct {
+	style Style
+	once  sync.Once
+	m     map[string]string
+}
+
+var (
+	// styleTables 各种拼音风格的音节表: Style -> *styleTable
+	styleTables sync.Map
+
+	syllablesOnce sync.Once
+	syllables     []string // PinyinDict 及 PhraseDict 中的全部音节
+)
+
+// dictSyllables 返回 PinyinDict 及 PhraseDict 中的全部音节
+func dictSyllables() []string {
+	syllablesOnce.Do(func() {
+		seen := map[string]bool{}
+		add := func(py string) {
+			if !seen[py] {
+				seen[py] = true
+				syllables = append(syllables, py)
+			}
+		}
+		for _, value := range PinyinDict {
+			for _, py := range strings.Split(value, ",") {
+				add(py)
+			}
+		}
+		for _, value := range PhraseDict {
+			for _, py := range strings.Fields(value) {
+				add(py)
+			}
+		}
+	})
+	return syllables
+}
+
+// syllableTable 返回 a 的拼音风格的音节表：每个词典音节 -> 按风格处理的结果.
+// The tables are computed once per style and shared by all the converters,
+// so that shaping a syllable is a mere table lookup.
+func (a Pinyin) syllableTable() *styleTable {
+	v, _ := styleTables.LoadOrStore(a.Style, &styleTable{style: a.Style})
+	t := v.(*styleTable)
+	t.once.Do(func() {
+		t.m = make(map[string]string, len(dictSyllables()))
+		for _, py := range dictSyllables() {
+			if sy, ok := parseSyllable(py); ok {
+				t.m[py] = a.Render(sy)
+			}
+		}
+	})
+	return t
+}
+
+// shapeOne 按拼音风格处理读音 py，查音节表，不在表中的才解析 py；
+// py 不是已知的音节时原样返回
+func (a Pinyin) shapeOne(py string) string {
+	t := a.table
+	if t == nil || t.style != a.Style {
+		// Style 在构造之后被修改
+		t = a.syllableTable()
+	}
+	if v, ok := t.m[py]; ok {
+		return v
+	}
+	if sy, ok := parseSyllable(py); ok && knownSyllable(sy.plain) {
+		return a.Render(sy)
+	}
+	return py
+}
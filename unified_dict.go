@@ -0,0 +1,1327 @@
+package pinyin
+
+// UnifiedDict maps the CJK compatibility ideographs, Kangxi radicals and
+// CJK radicals supplement to their unified ideographs.
+// Warning: Auto-generated file (NFKC, plus the radical forms by name), don't edit.
+var UnifiedDict = map[int]int{
+	0x2E81:  0x5382,  // ⺁ -> 厂
+	0x2E82:  0x4E5A,  // ⺂ -> 乚
+	0x2E83:  0x4E5B,  // ⺃ -> 乛
+	0x2E85:  0x4EBB,  // ⺅ -> 亻
+	0x2E86:  0x5182,  // ⺆ -> 冂
+	0x2E87:  0x51E0,  // ⺇ -> 几
+	0x2E89:  0x5202,  // ⺉ -> 刂
+	0x2E8A:  0x535C,  // ⺊ -> 卜
+	0x2E8B:  0x353E,  // ⺋ -> 㔾
+	0x2E8C:  0x5C0F,  // ⺌ -> 小
+	0x2E8D:  0x5C0F,  // ⺍ -> 小
+	0x2E8E:  0x5140,  // ⺎ -> 兀
+	0x2E8F:  0x5C23,  // ⺏ -> 尣
+	0x2E90:  0x5C22,  // ⺐ -> 尢
+	0x2E92:  0x5DF3,  // ⺒ -> 巳
+	0x2E93:  0x5E7A,  // ⺓ -> 幺
+	0x2E94:  0x5F51,  // ⺔ -> 彑
+	0x2E95:  0x5F50,  // ⺕ -> 彐
+	0x2E96:  0x5FC4,  // ⺖ -> 忄
+	0x2E97:  0x38FA,  // ⺗ -> 㣺
+	0x2E98:  0x624C,  // ⺘ -> 扌
+	0x2E99:  0x6535,  // ⺙ -> 攵
+	0x2E9B:  0x65E1,  // ⺛ -> 旡
+	0x2E9C:  0x65E5,  // ⺜ -> 日
+	0x2E9D:  0x6708,  // ⺝ -> 月
+	0x2E9E:  0x6B7A,  // ⺞ -> 歺
+	0x2E9F:  0x6BCD,  // ⺟ -> 母
+	0x2EA0:  0x6C11,  // ⺠ -> 民
+	0x2EA1:  0x6C35,  // ⺡ -> 氵
+	0x2EA2:  0x6C3A,  // ⺢ -> 氺
+	0x2EA3:  0x706C,  // ⺣ -> 灬
+	0x2EA4:  0x722B,  // ⺤ -> 爫
+	0x2EA5:  0x722B,  // ⺥ -> 爫
+	0x2EA6:  0x4E2C,  // ⺦ -> 丬
+	0x2EA7:  0x725B,  // ⺧ -> 牛
+	0x2EA8:  0x72AD,  // ⺨ -> 犭
+	0x2EA9:  0x738B,  // ⺩ -> 王
+	0x2EAA:  0x758B,  // ⺪ -> 疋
+	0x2EAB:  0x7F52,  // ⺫ -> 罒
+	0x2EAC:  0x793A,  // ⺬ -> 示
+	0x2EAD:  0x793B,  // ⺭ -> 礻
+	0x2EAE:  0x25AD7, // ⺮ -> 𥫗
+	0x2EAF:  0x7CF9,  // ⺯ -> 糹
+	0x2EB0:  0x7E9F,  // ⺰ -> 纟
+	0x2EB2:  0x7F52,  // ⺲ -> 罒
+	0x2EB3:  0x34C1,  // ⺳ -> 㓁
+	0x2EB6:  0x7F8A,  // ⺶ -> 羊
+	0x2EB7:  0x2634C, // ⺷ -> 𦍌
+	0x2EB9:  0x8002,  // ⺹ -> 耂
+	0x2EBA:  0x8080,  // ⺺ -> 肀
+	0x2EBC:  0x6708,  // ⺼ -> 月
+	0x2EBD:  0x81FC,  // ⺽ -> 臼
+	0x2EBE:  0x8279,  // ⺾ -> 艹
+	0x2EBF:  0x8279,  // ⺿ -> 艹
+	0x2EC0:  0x8279,  // ⻀ -> 艹
+	0x2EC1:  0x864E,  // ⻁ -> 虎
+	0x2EC2:  0x8864,  // ⻂ -> 衤
+	0x2EC3:  0x8980,  // ⻃ -> 覀
+	0x2EC4:  0x897F,  // ⻄ -> 西
+	0x2EC5:  0x89C1,  // ⻅ -> 见
+	0x2EC6:  0x89D2,  // ⻆ -> 角
+	0x2EC7:  0x89D2,  // ⻇ -> 角
+	0x2EC8:  0x8BA0,  // ⻈ -> 讠
+	0x2EC9:  0x8D1D,  // ⻉ -> 贝
+	0x2ECA:  0x27FB7, // ⻊ -> 𧾷
+	0x2ECB:  0x8F66,  // ⻋ -> 车
+	0x2ECC:  0x8FB6,  // ⻌ -> 辶
+	0x2ECD:  0x8FB6,  // ⻍ -> 辶
+	0x2ECE:  0x8FB6,  // ⻎ -> 辶
+	0x2ECF:  0x961D,  // ⻏ -> 阝
+	0x2ED0:  0x9485,  // ⻐ -> 钅
+	0x2ED1:  0x9577,  // ⻑ -> 長
+	0x2ED2:  0x9578,  // ⻒ -> 镸
+	0x2ED3:  0x957F,  // ⻓ -> 长
+	0x2ED4:  0x95E8,  // ⻔ -> 门
+	0x2ED6:  0x961D,  // ⻖ -> 阝
+	0x2ED7:  0x96E8,  // ⻗ -> 雨
+	0x2ED8:  0x9752,  // ⻘ -> 青
+	0x2ED9:  0x97E6,  // ⻙ -> 韦
+	0x2EDA:  0x9875,  // ⻚ -> 页
+	0x2EDB:  0x98CE,  // ⻛ -> 风
+	0x2EDC:  0x98DE,  // ⻜ -> 飞
+	0x2EDD:  0x98DF,  // ⻝ -> 食
+	0x2EDE:  0x2967F, // ⻞ -> 𩙿
+	0x2EDF:  0x98E0,  // ⻟ -> 飠
+	0x2EE0:  0x9963,  // ⻠ -> 饣
+	0x2EE2:  0x9A6C,  // ⻢ -> 马
+	0x2EE3:  0x9AA8,  // ⻣ -> 骨
+	0x2EE4:  0x9B3C,  // ⻤ -> 鬼
+	0x2EE5:  0x9C7C,  // ⻥ -> 鱼
+	0x2EE6:  0x9E1F,  // ⻦ -> 鸟
+	0x2EE7:  0x5364,  // ⻧ -> 卤
+	0x2EE8:  0x9EA6,  // ⻨ -> 麦
+	0x2EE9:  0x9EC4,  // ⻩ -> 黄
+	0x2EEA:  0x9EFE,  // ⻪ -> 黾
+	0x2EEB:  0x6589,  // ⻫ -> 斉
+	0x2EEC:  0x9F50,  // ⻬ -> 齐
+	0x2EED:  0x6B6F,  // ⻭ -> 歯
+	0x2EEE:  0x9F7F,  // ⻮ -> 齿
+	0x2EEF:  0x7ADC,  // ⻯ -> 竜
+	0x2EF0:  0x9F99,  // ⻰ -> 龙
+	0x2EF1:  0x9F9C,  // ⻱ -> 龜
+	0x2EF2:  0x4E80,  // ⻲ -> 亀
+	0x2EF3:  0x9F9F,  // ⻳ -> 龟
+	0x2F00:  0x4E00,  // ⼀ -> 一
+	0x2F01:  0x4E28,  // ⼁ -> 丨
+	0x2F02:  0x4E36,  // ⼂ -> 丶
+	0x2F03:  0x4E3F,  // ⼃ -> 丿
+	0x2F04:  0x4E59,  // ⼄ -> 乙
+	0x2F05:  0x4E85,  // ⼅ -> 亅
+	0x2F06:  0x4E8C,  // ⼆ -> 二
+	0x2F07:  0x4EA0,  // ⼇ -> 亠
+	0x2F08:  0x4EBA,  // ⼈ -> 人
+	0x2F09:  0x513F,  // ⼉ -> 儿
+	0x2F0A:  0x5165,  // ⼊ -> 入
+	0x2F0B:  0x516B,  // ⼋ -> 八
+	0x2F0C:  0x5182,  // ⼌ -> 冂
+	0x2F0D:  0x5196,  // ⼍ -> 冖
+	0x2F0E:  0x51AB,  // ⼎ -> 冫
+	0x2F0F:  0x51E0,  // ⼏ -> 几
+	0x2F10:  0x51F5,  // ⼐ -> 凵
+	0x2F11:  0x5200,  // ⼑ -> 刀
+	0x2F12:  0x529B,  // ⼒ -> 力
+	0x2F13:  0x52F9,  // ⼓ -> 勹
+	0x2F14:  0x5315,  // ⼔ -> 匕
+	0x2F15:  0x531A,  // ⼕ -> 匚
+	0x2F16:  0x5338,  // ⼖ -> 匸
+	0x2F17:  0x5341,  // ⼗ -> 十
+	0x2F18:  0x535C,  // ⼘ -> 卜
+	0x2F19:  0x5369,  // ⼙ -> 卩
+	0x2F1A:  0x5382,  // ⼚ -> 厂
+	0x2F1B:  0x53B6,  // ⼛ -> 厶
+	0x2F1C:  0x53C8,  // ⼜ -> 又
+	0x2F1D:  0x53E3,  // ⼝ -> 口
+	0x2F1E:  0x56D7,  // ⼞ -> 囗
+	0x2F1F:  0x571F,  // ⼟ -> 土
+	0x2F20:  0x58EB,  // ⼠ -> 士
+	0x2F21:  0x5902,  // ⼡ -> 夂
+	0x2F22:  0x590A,  // ⼢ -> 夊
+	0x2F23:  0x5915,  // ⼣ -> 夕
+	0x2F24:  0x5927,  // ⼤ -> 大
+	0x2F25:  0x5973,  // ⼥ -> 女
+	0x2F26:  0x5B50,  // ⼦ -> 子
+	0x2F27:  0x5B80,  // ⼧ -> 宀
+	0x2F28:  0x5BF8,  // ⼨ -> 寸
+	0x2F29:  0x5C0F,  // ⼩ -> 小
+	0x2F2A:  0x5C22,  // ⼪ -> 尢
+	0x2F2B:  0x5C38,  // ⼫ -> 尸
+	0x2F2C:  0x5C6E,  // ⼬ -> 屮
+	0x2F2D:  0x5C71,  // ⼭ -> 山
+	0x2F2E:  0x5DDB,  // ⼮ -> 巛
+	0x2F2F:  0x5DE5,  // ⼯ -> 工
+	0x2F30:  0x5DF1,  // ⼰ -> 己
+	0x2F31:  0x5DFE,  // ⼱ -> 巾
+	0x2F32:  0x5E72,  // ⼲ -> 干
+	0x2F33:  0x5E7A,  // ⼳ -> 幺
+	0x2F34:  0x5E7F,  // ⼴ -> 广
+	0x2F35:  0x5EF4,  // ⼵ -> 廴
+	0x2F36:  0x5EFE,  // ⼶ -> 廾
+	0x2F37:  0x5F0B,  // ⼷ -> 弋
+	0x2F38:  0x5F13,  // ⼸ -> 弓
+	0x2F39:  0x5F50,  // ⼹ -> 彐
+	0x2F3A:  0x5F61,  // ⼺ -> 彡
+	0x2F3B:  0x5F73,  // ⼻ -> 彳
+	0x2F3C:  0x5FC3,  // ⼼ -> 心
+	0x2F3D:  0x6208,  // ⼽ -> 戈
+	0x2F3E:  0x6236,  // ⼾ -> 戶
+	0x2F3F:  0x624B,  // ⼿ -> 手
+	0x2F40:  0x652F,  // ⽀ -> 支
+	0x2F41:  0x6534,  // ⽁ -> 攴
+	0x2F42:  0x6587,  // ⽂ -> 文
+	0x2F43:  0x6597,  // ⽃ -> 斗
+	0x2F44:  0x65A4,  // ⽄ -> 斤
+	0x2F45:  0x65B9,  // ⽅ -> 方
+	0x2F46:  0x65E0,  // ⽆ -> 无
+	0x2F47:  0x65E5,  // ⽇ -> 日
+	0x2F48:  0x66F0,  // ⽈ -> 曰
+	0x2F49:  0x6708,  // ⽉ -> 月
+	0x2F4A:  0x6728,  // ⽊ -> 木
+	0x2F4B:  0x6B20,  // ⽋ -> 欠
+	0x2F4C:  0x6B62,  // ⽌ -> 止
+	0x2F4D:  0x6B79,  // ⽍ -> 歹
+	0x2F4E:  0x6BB3,  // ⽎ -> 殳
+	0x2F4F:  0x6BCB,  // ⽏ -> 毋
+	0x2F50:  0x6BD4,  // ⽐ -> 比
+	0x2F51:  0x6BDB,  // ⽑ -> 毛
+	0x2F52:  0x6C0F,  // ⽒ -> 氏
+	0x2F53:  0x6C14,  // ⽓ -> 气
+	0x2F54:  0x6C34,  // ⽔ -> 水
+	0x2F55:  0x706B,  // ⽕ -> 火
+	0x2F56:  0x722A,  // ⽖ -> 爪
+	0x2F57:  0x7236,  // ⽗ -> 父
+	0x2F58:  0x723B,  // ⽘ -> 爻
+	0x2F59:  0x723F,  // ⽙ -> 爿
+	0x2F5A:  0x7247,  // ⽚ -> 片
+	0x2F5B:  0x7259,  // ⽛ -> 牙
+	0x2F5C:  0x725B,  // ⽜ -> 牛
+	0x2F5D:  0x72AC,  // ⽝ -> 犬
+	0x2F5E:  0x7384,  // ⽞ -> 玄
+	0x2F5F:  0x7389,  // ⽟ -> 玉
+	0x2F60:  0x74DC,  // ⽠ -> 瓜
+	0x2F61:  0x74E6,  // ⽡ -> 瓦
+	0x2F62:  0x7518,  // ⽢ -> 甘
+	0x2F63:  0x751F,  // ⽣ -> 生
+	0x2F64:  0x7528,  // ⽤ -> 用
+	0x2F65:  0x7530,  // ⽥ -> 田
+	0x2F66:  0x758B,  // ⽦ -> 疋
+	0x2F67:  0x7592,  // ⽧ -> 疒
+	0x2F68:  0x7676,  // ⽨ -> 癶
+	0x2F69:  0x767D,  // ⽩ -> 白
+	0x2F6A:  0x76AE,  // ⽪ -> 皮
+	0x2F6B:  0x76BF,  // ⽫ -> 皿
+	0x2F6C:  0x76EE,  // ⽬ -> 目
+	0x2F6D:  0x77DB,  // ⽭ -> 矛
+	0x2F6E:  0x77E2,  // ⽮ -> 矢
+	0x2F6F:  0x77F3,  // ⽯ -> 石
+	0x2F70:  0x793A,  // ⽰ -> 示
+	0x2F71:  0x79B8,  // ⽱ -> 禸
+	0x2F72:  0x79BE,  // ⽲ -> 禾
+	0x2F73:  0x7A74,  // ⽳ -> 穴
+	0x2F74:  0x7ACB,  // ⽴ -> 立
+	0x2F75:  0x7AF9,  // ⽵ -> 竹
+	0x2F76:  0x7C73,  // ⽶ -> 米
+	0x2F77:  0x7CF8,  // ⽷ -> 糸
+	0x2F78:  0x7F36,  // ⽸ -> 缶
+	0x2F79:  0x7F51,  // ⽹ -> 网
+	0x2F7A:  0x7F8A,  // ⽺ -> 羊
+	0x2F7B:  0x7FBD,  // ⽻ -> 羽
+	0x2F7C:  0x8001,  // ⽼ -> 老
+	0x2F7D:  0x800C,  // ⽽ -> 而
+	0x2F7E:  0x8012,  // ⽾ -> 耒
+	0x2F7F:  0x8033,  // ⽿ -> 耳
+	0x2F80:  0x807F,  // ⾀ -> 聿
+	0x2F81:  0x8089,  // ⾁ -> 肉
+	0x2F82:  0x81E3,  // ⾂ -> 臣
+	0x2F83:  0x81EA,  // ⾃ -> 自
+	0x2F84:  0x81F3,  // ⾄ -> 至
+	0x2F85:  0x81FC,  // ⾅ -> 臼
+	0x2F86:  0x820C,  // ⾆ -> 舌
+	0x2F87:  0x821B,  // ⾇ -> 舛
+	0x2F88:  0x821F,  // ⾈ -> 舟
+	0x2F89:  0x826E,  // ⾉ -> 艮
+	0x2F8A:  0x8272,  // ⾊ -> 色
+	0x2F8B:  0x8278,  // ⾋ -> 艸
+	0x2F8C:  0x864D,  // ⾌ -> 虍
+	0x2F8D:  0x866B,  // ⾍ -> 虫
+	0x2F8E:  0x8840,  // ⾎ -> 血
+	0x2F8F:  0x884C,  // ⾏ -> 行
+	0x2F90:  0x8863,  // ⾐ -> 衣
+	0x2F91:  0x897E,  // ⾑ -> 襾
+	0x2F92:  0x898B,  // ⾒ -> 見
+	0x2F93:  0x89D2,  // ⾓ -> 角
+	0x2F94:  0x8A00,  // ⾔ -> 言
+	0x2F95:  0x8C37,  // ⾕ -> 谷
+	0x2F96:  0x8C46,  // ⾖ -> 豆
+	0x2F97:  0x8C55,  // ⾗ -> 豕
+	0x2F98:  0x8C78,  // ⾘ -> 豸
+	0x2F99:  0x8C9D,  // ⾙ -> 貝
+	0x2F9A:  0x8D64,  // ⾚ -> 赤
+	0x2F9B:  0x8D70,  // ⾛ -> 走
+	0x2F9C:  0x8DB3,  // ⾜ -> 足
+	0x2F9D:  0x8EAB,  // ⾝ -> 身
+	0x2F9E:  0x8ECA,  // ⾞ -> 車
+	0x2F9F:  0x8F9B,  // ⾟ -> 辛
+	0x2FA0:  0x8FB0,  // ⾠ -> 辰
+	0x2FA1:  0x8FB5,  // ⾡ -> 辵
+	0x2FA2:  0x9091,  // ⾢ -> 邑
+	0x2FA3:  0x9149,  // ⾣ -> 酉
+	0x2FA4:  0x91C6,  // ⾤ -> 釆
+	0x2FA5:  0x91CC,  // ⾥ -> 里
+	0x2FA6:  0x91D1,  // ⾦ -> 金
+	0x2FA7:  0x9577,  // ⾧ -> 長
+	0x2FA8:  0x9580,  // ⾨ -> 門
+	0x2FA9:  0x961C,  // ⾩ -> 阜
+	0x2FAA:  0x96B6,  // ⾪ -> 隶
+	0x2FAB:  0x96B9,  // ⾫ -> 隹
+	0x2FAC:  0x96E8,  // ⾬ -> 雨
+	0x2FAD:  0x9751,  // ⾭ -> 靑
+	0x2FAE:  0x975E,  // ⾮ -> 非
+	0x2FAF:  0x9762,  // ⾯ -> 面
+	0x2FB0:  0x9769,  // ⾰ -> 革
+	0x2FB1:  0x97CB,  // ⾱ -> 韋
+	0x2FB2:  0x97ED,  // ⾲ -> 韭
+	0x2FB3:  0x97F3,  // ⾳ -> 音
+	0x2FB4:  0x9801,  // ⾴ -> 頁
+	0x2FB5:  0x98A8,  // ⾵ -> 風
+	0x2FB6:  0x98DB,  // ⾶ -> 飛
+	0x2FB7:  0x98DF,  // ⾷ -> 食
+	0x2FB8:  0x9996,  // ⾸ -> 首
+	0x2FB9:  0x9999,  // ⾹ -> 香
+	0x2FBA:  0x99AC,  // ⾺ -> 馬
+	0x2FBB:  0x9AA8,  // ⾻ -> 骨
+	0x2FBC:  0x9AD8,  // ⾼ -> 高
+	0x2FBD:  0x9ADF,  // ⾽ -> 髟
+	0x2FBE:  0x9B25,  // ⾾ -> 鬥
+	0x2FBF:  0x9B2F,  // ⾿ -> 鬯
+	0x2FC0:  0x9B32,  // ⿀ -> 鬲
+	0x2FC1:  0x9B3C,  // ⿁ -> 鬼
+	0x2FC2:  0x9B5A,  // ⿂ -> 魚
+	0x2FC3:  0x9CE5,  // ⿃ -> 鳥
+	0x2FC4:  0x9E75,  // ⿄ -> 鹵
+	0x2FC5:  0x9E7F,  // ⿅ -> 鹿
+	0x2FC6:  0x9EA5,  // ⿆ -> 麥
+	0x2FC7:  0x9EBB,  // ⿇ -> 麻
+	0x2FC8:  0x9EC3,  // ⿈ -> 黃
+	0x2FC9:  0x9ECD,  // ⿉ -> 黍
+	0x2FCA:  0x9ED1,  // ⿊ -> 黑
+	0x2FCB:  0x9EF9,  // ⿋ -> 黹
+	0x2FCC:  0x9EFD,  // ⿌ -> 黽
+	0x2FCD:  0x9F0E,  // ⿍ -> 鼎
+	0x2FCE:  0x9F13,  // ⿎ -> 鼓
+	0x2FCF:  0x9F20,  // ⿏ -> 鼠
+	0x2FD0:  0x9F3B,  // ⿐ -> 鼻
+	0x2FD1:  0x9F4A,  // ⿑ -> 齊
+	0x2FD2:  0x9F52,  // ⿒ -> 齒
+	0x2FD3:  0x9F8D,  // ⿓ -> 龍
+	0x2FD4:  0x9F9C,  // ⿔ -> 龜
+	0x2FD5:  0x9FA0,  // ⿕ -> 龠
+	0xF900:  0x8C48,  // 豈 -> 豈
+	0xF901:  0x66F4,  // 更 -> 更
+	0xF902:  0x8ECA,  // 車 -> 車
+	0xF903:  0x8CC8,  // 賈 -> 賈
+	0xF904:  0x6ED1,  // 滑 -> 滑
+	0xF905:  0x4E32,  // 串 -> 串
+	0xF906:  0x53E5,  // 句 -> 句
+	0xF907:  0x9F9C,  // 龜 -> 龜
+	0xF908:  0x9F9C,  // 龜 -> 龜
+	0xF909:  0x5951,  // 契 -> 契
+	0xF90A:  0x91D1,  // 金 -> 金
+	0xF90B:  0x5587,  // 喇 -> 喇
+	0xF90C:  0x5948,  // 奈 -> 奈
+	0xF90D:  0x61F6,  // 懶 -> 懶
+	0xF90E:  0x7669,  // 癩 -> 癩
+	0xF90F:  0x7F85,  // 羅 -> 羅
+	0xF910:  0x863F,  // 蘿 -> 蘿
+	0xF911:  0x87BA,  // 螺 -> 螺
+	0xF912:  0x88F8,  // 裸 -> 裸
+	0xF913:  0x908F,  // 邏 -> 邏
+	0xF914:  0x6A02,  // 樂 -> 樂
+	0xF915:  0x6D1B,  // 洛 -> 洛
+	0xF916:  0x70D9,  // 烙 -> 烙
+	0xF917:  0x73DE,  // 珞 -> 珞
+	0xF918:  0x843D,  // 落 -> 落
+	0xF919:  0x916A,  // 酪 -> 酪
+	0xF91A:  0x99F1,  // 駱 -> 駱
+	0xF91B:  0x4E82,  // 亂 -> 亂
+	0xF91C:  0x5375,  // 卵 -> 卵
+	0xF91D:  0x6B04,  // 欄 -> 欄
+	0xF91E:  0x721B,  // 爛 -> 爛
+	0xF91F:  0x862D,  // 蘭 -> 蘭
+	0xF920:  0x9E1E,  // 鸞 -> 鸞
+	0xF921:  0x5D50,  // 嵐 -> 嵐
+	0xF922:  0x6FEB,  // 濫 -> 濫
+	0xF923:  0x85CD,  // 藍 -> 藍
+	0xF924:  0x8964,  // 襤 -> 襤
+	0xF925:  0x62C9,  // 拉 -> 拉
+	0xF926:  0x81D8,  // 臘 -> 臘
+	0xF927:  0x881F,  // 蠟 -> 蠟
+	0xF928:  0x5ECA,  // 廊 -> 廊
+	0xF929:  0x6717,  // 朗 -> 朗
+	0xF92A:  0x6D6A,  // 浪 -> 浪
+	0xF92B:  0x72FC,  // 狼 -> 狼
+	0xF92C:  0x90CE,  // 郎 -> 郎
+	0xF92D:  0x4F86,  // 來 -> 來
+	0xF92E:  0x51B7,  // 冷 -> 冷
+	0xF92F:  0x52DE,  // 勞 -> 勞
+	0xF930:  0x64C4,  // 擄 -> 擄
+	0xF931:  0x6AD3,  // 櫓 -> 櫓
+	0xF932:  0x7210,  // 爐 -> 爐
+	0xF933:  0x76E7,  // 盧 -> 盧
+	0xF934:  0x8001,  // 老 -> 老
+	0xF935:  0x8606,  // 蘆 -> 蘆
+	0xF936:  0x865C,  // 虜 -> 虜
+	0xF937:  0x8DEF,  // 路 -> 路
+	0xF938:  0x9732,  // 露 -> 露
+	0xF939:  0x9B6F,  // 魯 -> 魯
+	0xF93A:  0x9DFA,  // 鷺 -> 鷺
+	0xF93B:  0x788C,  // 碌 -> 碌
+	0xF93C:  0x797F,  // 祿 -> 祿
+	0xF93D:  0x7DA0,  // 綠 -> 綠
+	0xF93E:  0x83C9,  // 菉 -> 菉
+	0xF93F:  0x9304,  // 錄 -> 錄
+	0xF940:  0x9E7F,  // 鹿 -> 鹿
+	0xF941:  0x8AD6,  // 論 -> 論
+	0xF942:  0x58DF,  // 壟 -> 壟
+	0xF943:  0x5F04,  // 弄 -> 弄
+	0xF944:  0x7C60,  // 籠 -> 籠
+	0xF945:  0x807E,  // 聾 -> 聾
+	0xF946:  0x7262,  // 牢 -> 牢
+	0xF947:  0x78CA,  // 磊 -> 磊
+	0xF948:  0x8CC2,  // 賂 -> 賂
+	0xF949:  0x96F7,  // 雷 -> 雷
+	0xF94A:  0x58D8,  // 壘 -> 壘
+	0xF94B:  0x5C62,  // 屢 -> 屢
+	0xF94C:  0x6A13,  // 樓 -> 樓
+	0xF94D:  0x6DDA,  // 淚 -> 淚
+	0xF94E:  0x6F0F,  // 漏 -> 漏
+	0xF94F:  0x7D2F,  // 累 -> 累
+	0xF950:  0x7E37,  // 縷 -> 縷
+	0xF951:  0x964B,  // 陋 -> 陋
+	0xF952:  0x52D2,  // 勒 -> 勒
+	0xF953:  0x808B,  // 肋 -> 肋
+	0xF954:  0x51DC,  // 凜 -> 凜
+	0xF955:  0x51CC,  // 凌 -> 凌
+	0xF956:  0x7A1C,  // 稜 -> 稜
+	0xF957:  0x7DBE,  // 綾 -> 綾
+	0xF958:  0x83F1,  // 菱 -> 菱
+	0xF959:  0x9675,  // 陵 -> 陵
+	0xF95A:  0x8B80,  // 讀 -> 讀
+	0xF95B:  0x62CF,  // 拏 -> 拏
+	0xF95C:  0x6A02,  // 樂 -> 樂
+	0xF95D:  0x8AFE,  // 諾 -> 諾
+	0xF95E:  0x4E39,  // 丹 -> 丹
+	0xF95F:  0x5BE7,  // 寧 -> 寧
+	0xF960:  0x6012,  // 怒 -> 怒
+	0xF961:  0x7387,  // 率 -> 率
+	0xF962:  0x7570,  // 異 -> 異
+	0xF963:  0x5317,  // 北 -> 北
+	0xF964:  0x78FB,  // 磻 -> 磻
+	0xF965:  0x4FBF,  // 便 -> 便
+	0xF966:  0x5FA9,  // 復 -> 復
+	0xF967:  0x4E0D,  // 不 -> 不
+	0xF968:  0x6CCC,  // 泌 -> 泌
+	0xF969:  0x6578,  // 數 -> 數
+	0xF96A:  0x7D22,  // 索 -> 索
+	0xF96B:  0x53C3,  // 參 -> 參
+	0xF96C:  0x585E,  // 塞 -> 塞
+	0xF96D:  0x7701,  // 省 -> 省
+	0xF96E:  0x8449,  // 葉 -> 葉
+	0xF96F:  0x8AAA,  // 說 -> 說
+	0xF970:  0x6BBA,  // 殺 -> 殺
+	0xF971:  0x8FB0,  // 辰 -> 辰
+	0xF972:  0x6C88,  // 沈 -> 沈
+	0xF973:  0x62FE,  // 拾 -> 拾
+	0xF974:  0x82E5,  // 若 -> 若
+	0xF975:  0x63A0,  // 掠 -> 掠
+	0xF976:  0x7565,  // 略 -> 略
+	0xF977:  0x4EAE,  // 亮 -> 亮
+	0xF978:  0x5169,  // 兩 -> 兩
+	0xF979:  0x51C9,  // 凉 -> 凉
+	0xF97A:  0x6881,  // 梁 -> 梁
+	0xF97B:  0x7CE7,  // 糧 -> 糧
+	0xF97C:  0x826F,  // 良 -> 良
+	0xF97D:  0x8AD2,  // 諒 -> 諒
+	0xF97E:  0x91CF,  // 量 -> 量
+	0xF97F:  0x52F5,  // 勵 -> 勵
+	0xF980:  0x5442,  // 呂 -> 呂
+	0xF981:  0x5973,  // 女 -> 女
+	0xF982:  0x5EEC,  // 廬 -> 廬
+	0xF983:  0x65C5,  // 旅 -> 旅
+	0xF984:  0x6FFE,  // 濾 -> 濾
+	0xF985:  0x792A,  // 礪 -> 礪
+	0xF986:  0x95AD,  // 閭 -> 閭
+	0xF987:  0x9A6A,  // 驪 -> 驪
+	0xF988:  0x9E97,  // 麗 -> 麗
+	0xF989:  0x9ECE,  // 黎 -> 黎
+	0xF98A:  0x529B,  // 力 -> 力
+	0xF98B:  0x66C6,  // 曆 -> 曆
+	0xF98C:  0x6B77,  // 歷 -> 歷
+	0xF98D:  0x8F62,  // 轢 -> 轢
+	0xF98E:  0x5E74,  // 年 -> 年
+	0xF98F:  0x6190,  // 憐 -> 憐
+	0xF990:  0x6200,  // 戀 -> 戀
+	0xF991:  0x649A,  // 撚 -> 撚
+	0xF992:  0x6F23,  // 漣 -> 漣
+	0xF993:  0x7149,  // 煉 -> 煉
+	0xF994:  0x7489,  // 璉 -> 璉
+	0xF995:  0x79CA,  // 秊 -> 秊
+	0xF996:  0x7DF4,  // 練 -> 練
+	0xF997:  0x806F,  // 聯 -> 聯
+	0xF998:  0x8F26,  // 輦 -> 輦
+	0xF999:  0x84EE,  // 蓮 -> 蓮
+	0xF99A:  0x9023,  // 連 -> 連
+	0xF99B:  0x934A,  // 鍊 -> 鍊
+	0xF99C:  0x5217,  // 列 -> 列
+	0xF99D:  0x52A3,  // 劣 -> 劣
+	0xF99E:  0x54BD,  // 咽 -> 咽
+	0xF99F:  0x70C8,  // 烈 -> 烈
+	0xF9A0:  0x88C2,  // 裂 -> 裂
+	0xF9A1:  0x8AAA,  // 說 -> 說
+	0xF9A2:  0x5EC9,  // 廉 -> 廉
+	0xF9A3:  0x5FF5,  // 念 -> 念
+	0xF9A4:  0x637B,  // 捻 -> 捻
+	0xF9A5:  0x6BAE,  // 殮 -> 殮
+	0xF9A6:  0x7C3E,  // 簾 -> 簾
+	0xF9A7:  0x7375,  // 獵 -> 獵
+	0xF9A8:  0x4EE4,  // 令 -> 令
+	0xF9A9:  0x56F9,  // 囹 -> 囹
+	0xF9AA:  0x5BE7,  // 寧 -> 寧
+	0xF9AB:  0x5DBA,  // 嶺 -> 嶺
+	0xF9AC:  0x601C,  // 怜 -> 怜
+	0xF9AD:  0x73B2,  // 玲 -> 玲
+	0xF9AE:  0x7469,  // 瑩 -> 瑩
+	0xF9AF:  0x7F9A,  // 羚 -> 羚
+	0xF9B0:  0x8046,  // 聆 -> 聆
+	0xF9B1:  0x9234,  // 鈴 -> 鈴
+	0xF9B2:  0x96F6,  // 零 -> 零
+	0xF9B3:  0x9748,  // 靈 -> 靈
+	0xF9B4:  0x9818,  // 領 -> 領
+	0xF9B5:  0x4F8B,  // 例 -> 例
+	0xF9B6:  0x79AE,  // 禮 -> 禮
+	0xF9B7:  0x91B4,  // 醴 -> 醴
+	0xF9B8:  0x96B8,  // 隸 -> 隸
+	0xF9B9:  0x60E1,  // 惡 -> 惡
+	0xF9BA:  0x4E86,  // 了 -> 了
+	0xF9BB:  0x50DA,  // 僚 -> 僚
+	0xF9BC:  0x5BEE,  // 寮 -> 寮
+	0xF9BD:  0x5C3F,  // 尿 -> 尿
+	0xF9BE:  0x6599,  // 料 -> 料
+	0xF9BF:  0x6A02,  // 樂 -> 樂
+	0xF9C0:  0x71CE,  // 燎 -> 燎
+	0xF9C1:  0x7642,  // 療 -> 療
+	0xF9C2:  0x84FC,  // 蓼 -> 蓼
+	0xF9C3:  0x907C,  // 遼 -> 遼
+	0xF9C4:  0x9F8D,  // 龍 -> 龍
+	0xF9C5:  0x6688,  // 暈 -> 暈
+	0xF9C6:  0x962E,  // 阮 -> 阮
+	0xF9C7:  0x5289,  // 劉 -> 劉
+	0xF9C8:  0x677B,  // 杻 -> 杻
+	0xF9C9:  0x67F3,  // 柳 -> 柳
+	0xF9CA:  0x6D41,  // 流 -> 流
+	0xF9CB:  0x6E9C,  // 溜 -> 溜
+	0xF9CC:  0x7409,  // 琉 -> 琉
+	0xF9CD:  0x7559,  // 留 -> 留
+	0xF9CE:  0x786B,  // 硫 -> 硫
+	0xF9CF:  0x7D10,  // 紐 -> 紐
+	0xF9D0:  0x985E,  // 類 -> 類
+	0xF9D1:  0x516D,  // 六 -> 六
+	0xF9D2:  0x622E,  // 戮 -> 戮
+	0xF9D3:  0x9678,  // 陸 -> 陸
+	0xF9D4:  0x502B,  // 倫 -> 倫
+	0xF9D5:  0x5D19,  // 崙 -> 崙
+	0xF9D6:  0x6DEA,  // 淪 -> 淪
+	0xF9D7:  0x8F2A,  // 輪 -> 輪
+	0xF9D8:  0x5F8B,  // 律 -> 律
+	0xF9D9:  0x6144,  // 慄 -> 慄
+	0xF9DA:  0x6817,  // 栗 -> 栗
+	0xF9DB:  0x7387,  // 率 -> 率
+	0xF9DC:  0x9686,  // 隆 -> 隆
+	0xF9DD:  0x5229,  // 利 -> 利
+	0xF9DE:  0x540F,  // 吏 -> 吏
+	0xF9DF:  0x5C65,  // 履 -> 履
+	0xF9E0:  0x6613,  // 易 -> 易
+	0xF9E1:  0x674E,  // 李 -> 李
+	0xF9E2:  0x68A8,  // 梨 -> 梨
+	0xF9E3:  0x6CE5,  // 泥 -> 泥
+	0xF9E4:  0x7406,  // 理 -> 理
+	0xF9E5:  0x75E2,  // 痢 -> 痢
+	0xF9E6:  0x7F79,  // 罹 -> 罹
+	0xF9E7:  0x88CF,  // 裏 -> 裏
+	0xF9E8:  0x88E1,  // 裡 -> 裡
+	0xF9E9:  0x91CC,  // 里 -> 里
+	0xF9EA:  0x96E2,  // 離 -> 離
+	0xF9EB:  0x533F,  // 匿 -> 匿
+	0xF9EC:  0x6EBA,  // 溺 -> 溺
+	0xF9ED:  0x541D,  // 吝 -> 吝
+	0xF9EE:  0x71D0,  // 燐 -> 燐
+	0xF9EF:  0x7498,  // 璘 -> 璘
+	0xF9F0:  0x85FA,  // 藺 -> 藺
+	0xF9F1:  0x96A3,  // 隣 -> 隣
+	0xF9F2:  0x9C57,  // 鱗 -> 鱗
+	0xF9F3:  0x9E9F,  // 麟 -> 麟
+	0xF9F4:  0x6797,  // 林 -> 林
+	0xF9F5:  0x6DCB,  // 淋 -> 淋
+	0xF9F6:  0x81E8,  // 臨 -> 臨
+	0xF9F7:  0x7ACB,  // 立 -> 立
+	0xF9F8:  0x7B20,  // 笠 -> 笠
+	0xF9F9:  0x7C92,  // 粒 -> 粒
+	0xF9FA:  0x72C0,  // 狀 -> 狀
+	0xF9FB:  0x7099,  // 炙 -> 炙
+	0xF9FC:  0x8B58,  // 識 -> 識
+	0xF9FD:  0x4EC0,  // 什 -> 什
+	0xF9FE:  0x8336,  // 茶 -> 茶
+	0xF9FF:  0x523A,  // 刺 -> 刺
+	0xFA00:  0x5207,  // 切 -> 切
+	0xFA01:  0x5EA6,  // 度 -> 度
+	0xFA02:  0x62D3,  // 拓 -> 拓
+	0xFA03:  0x7CD6,  // 糖 -> 糖
+	0xFA04:  0x5B85,  // 宅 -> 宅
+	0xFA05:  0x6D1E,  // 洞 -> 洞
+	0xFA06:  0x66B4,  // 暴 -> 暴
+	0xFA07:  0x8F3B,  // 輻 -> 輻
+	0xFA08:  0x884C,  // 行 -> 行
+	0xFA09:  0x964D,  // 降 -> 降
+	0xFA0A:  0x898B,  // 見 -> 見
+	0xFA0B:  0x5ED3,  // 廓 -> 廓
+	0xFA0C:  0x5140,  // 兀 -> 兀
+	0xFA0D:  0x55C0,  // 嗀 -> 嗀
+	0xFA10:  0x585A,  // 塚 -> 塚
+	0xFA12:  0x6674,  // 晴 -> 晴
+	0xFA15:  0x51DE,  // 凞 -> 凞
+	0xFA16:  0x732A,  // 猪 -> 猪
+	0xFA17:  0x76CA,  // 益 -> 益
+	0xFA18:  0x793C,  // 礼 -> 礼
+	0xFA19:  0x795E,  // 神 -> 神
+	0xFA1A:  0x7965,  // 祥 -> 祥
+	0xFA1B:  0x798F,  // 福 -> 福
+	0xFA1C:  0x9756,  // 靖 -> 靖
+	0xFA1D:  0x7CBE,  // 精 -> 精
+	0xFA1E:  0x7FBD,  // 羽 -> 羽
+	0xFA20:  0x8612,  // 蘒 -> 蘒
+	0xFA22:  0x8AF8,  // 諸 -> 諸
+	0xFA25:  0x9038,  // 逸 -> 逸
+	0xFA26:  0x90FD,  // 都 -> 都
+	0xFA2A:  0x98EF,  // 飯 -> 飯
+	0xFA2B:  0x98FC,  // 飼 -> 飼
+	0xFA2C:  0x9928,  // 館 -> 館
+	0xFA2D:  0x9DB4,  // 鶴 -> 鶴
+	0xFA2E:  0x90DE,  // 郞 -> 郞
+	0xFA2F:  0x96B7,  // 隷 -> 隷
+	0xFA30:  0x4FAE,  // 侮 -> 侮
+	0xFA31:  0x50E7,  // 僧 -> 僧
+	0xFA32:  0x514D,  // 免 -> 免
+	0xFA33:  0x52C9,  // 勉 -> 勉
+	0xFA34:  0x52E4,  // 勤 -> 勤
+	0xFA35:  0x5351,  // 卑 -> 卑
+	0xFA36:  0x559D,  // 喝 -> 喝
+	0xFA37:  0x5606,  // 嘆 -> 嘆
+	0xFA38:  0x5668,  // 器 -> 器
+	0xFA39:  0x5840,  // 塀 -> 塀
+	0xFA3A:  0x58A8,  // 墨 -> 墨
+	0xFA3B:  0x5C64,  // 層 -> 層
+	0xFA3C:  0x5C6E,  // 屮 -> 屮
+	0xFA3D:  0x6094,  // 悔 -> 悔
+	0xFA3E:  0x6168,  // 慨 -> 慨
+	0xFA3F:  0x618E,  // 憎 -> 憎
+	0xFA40:  0x61F2,  // 懲 -> 懲
+	0xFA41:  0x654F,  // 敏 -> 敏
+	0xFA42:  0x65E2,  // 既 -> 既
+	0xFA43:  0x6691,  // 暑 -> 暑
+	0xFA44:  0x6885,  // 梅 -> 梅
+	0xFA45:  0x6D77,  // 海 -> 海
+	0xFA46:  0x6E1A,  // 渚 -> 渚
+	0xFA47:  0x6F22,  // 漢 -> 漢
+	0xFA48:  0x716E,  // 煮 -> 煮
+	0xFA49:  0x722B,  // 爫 -> 爫
+	0xFA4A:  0x7422,  // 琢 -> 琢
+	0xFA4B:  0x7891,  // 碑 -> 碑
+	0xFA4C:  0x793E,  // 社 -> 社
+	0xFA4D:  0x7949,  // 祉 -> 祉
+	0xFA4E:  0x7948,  // 祈 -> 祈
+	0xFA4F:  0x7950,  // 祐 -> 祐
+	0xFA50:  0x7956,  // 祖 -> 祖
+	0xFA51:  0x795D,  // 祝 -> 祝
+	0xFA52:  0x798D,  // 禍 -> 禍
+	0xFA53:  0x798E,  // 禎 -> 禎
+	0xFA54:  0x7A40,  // 穀 -> 穀
+	0xFA55:  0x7A81,  // 突 -> 突
+	0xFA56:  0x7BC0,  // 節 -> 節
+	0xFA57:  0x7DF4,  // 練 -> 練
+	0xFA58:  0x7E09,  // 縉 -> 縉
+	0xFA59:  0x7E41,  // 繁 -> 繁
+	0xFA5A:  0x7F72,  // 署 -> 署
+	0xFA5B:  0x8005,  // 者 -> 者
+	0xFA5C:  0x81ED,  // 臭 -> 臭
+	0xFA5D:  0x8279,  // 艹 -> 艹
+	0xFA5E:  0x8279,  // 艹 -> 艹
+	0xFA5F:  0x8457,  // 著 -> 著
+	0xFA60:  0x8910,  // 褐 -> 褐
+	0xFA61:  0x8996,  // 視 -> 視
+	0xFA62:  0x8B01,  // 謁 -> 謁
+	0xFA63:  0x8B39,  // 謹 -> 謹
+	0xFA64:  0x8CD3,  // 賓 -> 賓
+	0xFA65:  0x8D08,  // 贈 -> 贈
+	0xFA66:  0x8FB6,  // 辶 -> 辶
+	0xFA67:  0x9038,  // 逸 -> 逸
+	0xFA68:  0x96E3,  // 難 -> 難
+	0xFA69:  0x97FF,  // 響 -> 響
+	0xFA6A:  0x983B,  // 頻 -> 頻
+	0xFA6B:  0x6075,  // 恵 -> 恵
+	0xFA6C:  0x242EE, // 𤋮 -> 𤋮
+	0xFA6D:  0x8218,  // 舘 -> 舘
+	0xFA70:  0x4E26,  // 並 -> 並
+	0xFA71:  0x51B5,  // 况 -> 况
+	0xFA72:  0x5168,  // 全 -> 全
+	0xFA73:  0x4F80,  // 侀 -> 侀
+	0xFA74:  0x5145,  // 充 -> 充
+	0xFA75:  0x5180,  // 冀 -> 冀
+	0xFA76:  0x52C7,  // 勇 -> 勇
+	0xFA77:  0x52FA,  // 勺 -> 勺
+	0xFA78:  0x559D,  // 喝 -> 喝
+	0xFA79:  0x5555,  // 啕 -> 啕
+	0xFA7A:  0x5599,  // 喙 -> 喙
+	0xFA7B:  0x55E2,  // 嗢 -> 嗢
+	0xFA7C:  0x585A,  // 塚 -> 塚
+	0xFA7D:  0x58B3,  // 墳 -> 墳
+	0xFA7E:  0x5944,  // 奄 -> 奄
+	0xFA7F:  0x5954,  // 奔 -> 奔
+	0xFA80:  0x5A62,  // 婢 -> 婢
+	0xFA81:  0x5B28,  // 嬨 -> 嬨
+	0xFA82:  0x5ED2,  // 廒 -> 廒
+	0xFA83:  0x5ED9,  // 廙 -> 廙
+	0xFA84:  0x5F69,  // 彩 -> 彩
+	0xFA85:  0x5FAD,  // 徭 -> 徭
+	0xFA86:  0x60D8,  // 惘 -> 惘
+	0xFA87:  0x614E,  // 慎 -> 慎
+	0xFA88:  0x6108,  // 愈 -> 愈
+	0xFA89:  0x618E,  // 憎 -> 憎
+	0xFA8A:  0x6160,  // 慠 -> 慠
+	0xFA8B:  0x61F2,  // 懲 -> 懲
+	0xFA8C:  0x6234,  // 戴 -> 戴
+	0xFA8D:  0x63C4,  // 揄 -> 揄
+	0xFA8E:  0x641C,  // 搜 -> 搜
+	0xFA8F:  0x6452,  // 摒 -> 摒
+	0xFA90:  0x6556,  // 敖 -> 敖
+	0xFA91:  0x6674,  // 晴 -> 晴
+	0xFA92:  0x6717,  // 朗 -> 朗
+	0xFA93:  0x671B,  // 望 -> 望
+	0xFA94:  0x6756,  // 杖 -> 杖
+	0xFA95:  0x6B79,  // 歹 -> 歹
+	0xFA96:  0x6BBA,  // 殺 -> 殺
+	0xFA97:  0x6D41,  // 流 -> 流
+	0xFA98:  0x6EDB,  // 滛 -> 滛
+	0xFA99:  0x6ECB,  // 滋 -> 滋
+	0xFA9A:  0x6F22,  // 漢 -> 漢
+	0xFA9B:  0x701E,  // 瀞 -> 瀞
+	0xFA9C:  0x716E,  // 煮 -> 煮
+	0xFA9D:  0x77A7,  // 瞧 -> 瞧
+	0xFA9E:  0x7235,  // 爵 -> 爵
+	0xFA9F:  0x72AF,  // 犯 -> 犯
+	0xFAA0:  0x732A,  // 猪 -> 猪
+	0xFAA1:  0x7471,  // 瑱 -> 瑱
+	0xFAA2:  0x7506,  // 甆 -> 甆
+	0xFAA3:  0x753B,  // 画 -> 画
+	0xFAA4:  0x761D,  // 瘝 -> 瘝
+	0xFAA5:  0x761F,  // 瘟 -> 瘟
+	0xFAA6:  0x76CA,  // 益 -> 益
+	0xFAA7:  0x76DB,  // 盛 -> 盛
+	0xFAA8:  0x76F4,  // 直 -> 直
+	0xFAA9:  0x774A,  // 睊 -> 睊
+	0xFAAA:  0x7740,  // 着 -> 着
+	0xFAAB:  0x78CC,  // 磌 -> 磌
+	0xFAAC:  0x7AB1,  // 窱 -> 窱
+	0xFAAD:  0x7BC0,  // 節 -> 節
+	0xFAAE:  0x7C7B,  // 类 -> 类
+	0xFAAF:  0x7D5B,  // 絛 -> 絛
+	0xFAB0:  0x7DF4,  // 練 -> 練
+	0xFAB1:  0x7F3E,  // 缾 -> 缾
+	0xFAB2:  0x8005,  // 者 -> 者
+	0xFAB3:  0x8352,  // 荒 -> 荒
+	0xFAB4:  0x83EF,  // 華 -> 華
+	0xFAB5:  0x8779,  // 蝹 -> 蝹
+	0xFAB6:  0x8941,  // 襁 -> 襁
+	0xFAB7:  0x8986,  // 覆 -> 覆
+	0xFAB8:  0x8996,  // 視 -> 視
+	0xFAB9:  0x8ABF,  // 調 -> 調
+	0xFABA:  0x8AF8,  // 諸 -> 諸
+	0xFABB:  0x8ACB,  // 請 -> 請
+	0xFABC:  0x8B01,  // 謁 -> 謁
+	0xFABD:  0x8AFE,  // 諾 -> 諾
+	0xFABE:  0x8AED,  // 諭 -> 諭
+	0xFABF:  0x8B39,  // 謹 -> 謹
+	0xFAC0:  0x8B8A,  // 變 -> 變
+	0xFAC1:  0x8D08,  // 贈 -> 贈
+	0xFAC2:  0x8F38,  // 輸 -> 輸
+	0xFAC3:  0x9072,  // 遲 -> 遲
+	0xFAC4:  0x9199,  // 醙 -> 醙
+	0xFAC5:  0x9276,  // 鉶 -> 鉶
+	0xFAC6:  0x967C,  // 陼 -> 陼
+	0xFAC7:  0x96E3,  // 難 -> 難
+	0xFAC8:  0x9756,  // 靖 -> 靖
+	0xFAC9:  0x97DB,  // 韛 -> 韛
+	0xFACA:  0x97FF,  // 響 -> 響
+	0xFACB:  0x980B,  // 頋 -> 頋
+	0xFACC:  0x983B,  // 頻 -> 頻
+	0xFACD:  0x9B12,  // 鬒 -> 鬒
+	0xFACE:  0x9F9C,  // 龜 -> 龜
+	0xFACF:  0x2284A, // 𢡊 -> 𢡊
+	0xFAD0:  0x22844, // 𢡄 -> 𢡄
+	0xFAD1:  0x233D5, // 𣏕 -> 𣏕
+	0xFAD2:  0x3B9D,  // 㮝 -> 㮝
+	0xFAD3:  0x4018,  // 䀘 -> 䀘
+	0xFAD4:  0x4039,  // 䀹 -> 䀹
+	0xFAD5:  0x25249, // 𥉉 -> 𥉉
+	0xFAD6:  0x25CD0, // 𥳐 -> 𥳐
+	0xFAD7:  0x27ED3, // 𧻓 -> 𧻓
+	0xFAD8:  0x9F43,  // 齃 -> 齃
+	0xFAD9:  0x9F8E,  // 龎 -> 龎
+	0x2F800: 0x4E3D,  // 丽 -> 丽
+	0x2F801: 0x4E38,  // 丸 -> 丸
+	0x2F802: 0x4E41,  // 乁 -> 乁
+	0x2F803: 0x20122, // 𠄢 -> 𠄢
+	0x2F804: 0x4F60,  // 你 -> 你
+	0x2F805: 0x4FAE,  // 侮 -> 侮
+	0x2F806: 0x4FBB,  // 侻 -> 侻
+	0x2F807: 0x5002,  // 倂 -> 倂
+	0x2F808: 0x507A,  // 偺 -> 偺
+	0x2F809: 0x5099,  // 備 -> 備
+	0x2F80A: 0x50E7,  // 僧 -> 僧
+	0x2F80B: 0x50CF,  // 像 -> 像
+	0x2F80C: 0x349E,  // 㒞 -> 㒞
+	0x2F80D: 0x2063A, // 𠘺 -> 𠘺
+	0x2F80E: 0x514D,  // 免 -> 免
+	0x2F80F: 0x5154,  // 兔 -> 兔
+	0x2F810: 0x5164,  // 兤 -> 兤
+	0x2F811: 0x5177,  // 具 -> 具
+	0x2F812: 0x2051C, // 𠔜 -> 𠔜
+	0x2F813: 0x34B9,  // 㒹 -> 㒹
+	0x2F814: 0x5167,  // 內 -> 內
+	0x2F815: 0x518D,  // 再 -> 再
+	0x2F816: 0x2054B, // 𠕋 -> 𠕋
+	0x2F817: 0x5197,  // 冗 -> 冗
+	0x2F818: 0x51A4,  // 冤 -> 冤
+	0x2F819: 0x4ECC,  // 仌 -> 仌
+	0x2F81A: 0x51AC,  // 冬 -> 冬
+	0x2F81B: 0x51B5,  // 况 -> 况
+	0x2F81C: 0x291DF, // 𩇟 -> 𩇟
+	0x2F81D: 0x51F5,  // 凵 -> 凵
+	0x2F81E: 0x5203,  // 刃 -> 刃
+	0x2F81F: 0x34DF,  // 㓟 -> 㓟
+	0x2F820: 0x523B,  // 刻 -> 刻
+	0x2F821: 0x5246,  // 剆 -> 剆
+	0x2F822: 0x5272,  // 割 -> 割
+	0x2F823: 0x5277,  // 剷 -> 剷
+	0x2F824: 0x3515,  // 㔕 -> 㔕
+	0x2F825: 0x52C7,  // 勇 -> 勇
+	0x2F826: 0x52C9,  // 勉 -> 勉
+	0x2F827: 0x52E4,  // 勤 -> 勤
+	0x2F828: 0x52FA,  // 勺 -> 勺
+	0x2F829: 0x5305,  // 包 -> 包
+	0x2F82A: 0x5306,  // 匆 -> 匆
+	0x2F82B: 0x5317,  // 北 -> 北
+	0x2F82C: 0x5349,  // 卉 -> 卉
+	0x2F82D: 0x5351,  // 卑 -> 卑
+	0x2F82E: 0x535A,  // 博 -> 博
+	0x2F82F: 0x5373,  // 即 -> 即
+	0x2F830: 0x537D,  // 卽 -> 卽
+	0x2F831: 0x537F,  // 卿 -> 卿
+	0x2F832: 0x537F,  // 卿 -> 卿
+	0x2F833: 0x537F,  // 卿 -> 卿
+	0x2F834: 0x20A2C, // 𠨬 -> 𠨬
+	0x2F835: 0x7070,  // 灰 -> 灰
+	0x2F836: 0x53CA,  // 及 -> 及
+	0x2F837: 0x53DF,  // 叟 -> 叟
+	0x2F838: 0x20B63, // 𠭣 -> 𠭣
+	0x2F839: 0x53EB,  // 叫 -> 叫
+	0x2F83A: 0x53F1,  // 叱 -> 叱
+	0x2F83B: 0x5406,  // 吆 -> 吆
+	0x2F83C: 0x549E,  // 咞 -> 咞
+	0x2F83D: 0x5438,  // 吸 -> 吸
+	0x2F83E: 0x5448,  // 呈 -> 呈
+	0x2F83F: 0x5468,  // 周 -> 周
+	0x2F840: 0x54A2,  // 咢 -> 咢
+	0x2F841: 0x54F6,  // 哶 -> 哶
+	0x2F842: 0x5510,  // 唐 -> 唐
+	0x2F843: 0x5553,  // 啓 -> 啓
+	0x2F844: 0x5563,  // 啣 -> 啣
+	0x2F845: 0x5584,  // 善 -> 善
+	0x2F846: 0x5584,  // 善 -> 善
+	0x2F847: 0x5599,  // 喙 -> 喙
+	0x2F848: 0x55AB,  // 喫 -> 喫
+	0x2F849: 0x55B3,  // 喳 -> 喳
+	0x2F84A: 0x55C2,  // 嗂 -> 嗂
+	0x2F84B: 0x5716,  // 圖 -> 圖
+	0x2F84C: 0x5606,  // 嘆 -> 嘆
+	0x2F84D: 0x5717,  // 圗 -> 圗
+	0x2F84E: 0x5651,  // 噑 -> 噑
+	0x2F84F: 0x5674,  // 噴 -> 噴
+	0x2F850: 0x5207,  // 切 -> 切
+	0x2F851: 0x58EE,  // 壮 -> 壮
+	0x2F852: 0x57CE,  // 城 -> 城
+	0x2F853: 0x57F4,  // 埴 -> 埴
+	0x2F854: 0x580D,  // 堍 -> 堍
+	0x2F855: 0x578B,  // 型 -> 型
+	0x2F856: 0x5832,  // 堲 -> 堲
+	0x2F857: 0x5831,  // 報 -> 報
+	0x2F858: 0x58AC,  // 墬 -> 墬
+	0x2F859: 0x214E4, // 𡓤 -> 𡓤
+	0x2F85A: 0x58F2,  // 売 -> 売
+	0x2F85B: 0x58F7,  // 壷 -> 壷
+	0x2F85C: 0x5906,  // 夆 -> 夆
+	0x2F85D: 0x591A,  // 多 -> 多
+	0x2F85E: 0x5922,  // 夢 -> 夢
+	0x2F85F: 0x5962,  // 奢 -> 奢
+	0x2F860: 0x216A8, // 𡚨 -> 𡚨
+	0x2F861: 0x216EA, // 𡛪 -> 𡛪
+	0x2F862: 0x59EC,  // 姬 -> 姬
+	0x2F863: 0x5A1B,  // 娛 -> 娛
+	0x2F864: 0x5A27,  // 娧 -> 娧
+	0x2F865: 0x59D8,  // 姘 -> 姘
+	0x2F866: 0x5A66,  // 婦 -> 婦
+	0x2F867: 0x36EE,  // 㛮 -> 㛮
+	0x2F868: 0x36FC,  // 㛼 -> 㛼
+	0x2F869: 0x5B08,  // 嬈 -> 嬈
+	0x2F86A: 0x5B3E,  // 嬾 -> 嬾
+	0x2F86B: 0x5B3E,  // 嬾 -> 嬾
+	0x2F86C: 0x219C8, // 𡧈 -> 𡧈
+	0x2F86D: 0x5BC3,  // 寃 -> 寃
+	0x2F86E: 0x5BD8,  // 寘 -> 寘
+	0x2F86F: 0x5BE7,  // 寧 -> 寧
+	0x2F870: 0x5BF3,  // 寳 -> 寳
+	0x2F871: 0x21B18, // 𡬘 -> 𡬘
+	0x2F872: 0x5BFF,  // 寿 -> 寿
+	0x2F873: 0x5C06,  // 将 -> 将
+	0x2F874: 0x5F53,  // 当 -> 当
+	0x2F875: 0x5C22,  // 尢 -> 尢
+	0x2F876: 0x3781,  // 㞁 -> 㞁
+	0x2F877: 0x5C60,  // 屠 -> 屠
+	0x2F878: 0x5C6E,  // 屮 -> 屮
+	0x2F879: 0x5CC0,  // 峀 -> 峀
+	0x2F87A: 0x5C8D,  // 岍 -> 岍
+	0x2F87B: 0x21DE4, // 𡷤 -> 𡷤
+	0x2F87C: 0x5D43,  // 嵃 -> 嵃
+	0x2F87D: 0x21DE6, // 𡷦 -> 𡷦
+	0x2F87E: 0x5D6E,  // 嵮 -> 嵮
+	0x2F87F: 0x5D6B,  // 嵫 -> 嵫
+	0x2F880: 0x5D7C,  // 嵼 -> 嵼
+	0x2F881: 0x5DE1,  // 巡 -> 巡
+	0x2F882: 0x5DE2,  // 巢 -> 巢
+	0x2F883: 0x382F,  // 㠯 -> 㠯
+	0x2F884: 0x5DFD,  // 巽 -> 巽
+	0x2F885: 0x5E28,  // 帨 -> 帨
+	0x2F886: 0x5E3D,  // 帽 -> 帽
+	0x2F887: 0x5E69,  // 幩 -> 幩
+	0x2F888: 0x3862,  // 㡢 -> 㡢
+	0x2F889: 0x22183, // 𢆃 -> 𢆃
+	0x2F88A: 0x387C,  // 㡼 -> 㡼
+	0x2F88B: 0x5EB0,  // 庰 -> 庰
+	0x2F88C: 0x5EB3,  // 庳 -> 庳
+	0x2F88D: 0x5EB6,  // 庶 -> 庶
+	0x2F88E: 0x5ECA,  // 廊 -> 廊
+	0x2F88F: 0x2A392, // 𪎒 -> 𪎒
+	0x2F890: 0x5EFE,  // 廾 -> 廾
+	0x2F891: 0x22331, // 𢌱 -> 𢌱
+	0x2F892: 0x22331, // 𢌱 -> 𢌱
+	0x2F893: 0x8201,  // 舁 -> 舁
+	0x2F894: 0x5F22,  // 弢 -> 弢
+	0x2F895: 0x5F22,  // 弢 -> 弢
+	0x2F896: 0x38C7,  // 㣇 -> 㣇
+	0x2F897: 0x232B8, // 𣊸 -> 𣊸
+	0x2F898: 0x261DA, // 𦇚 -> 𦇚
+	0x2F899: 0x5F62,  // 形 -> 形
+	0x2F89A: 0x5F6B,  // 彫 -> 彫
+	0x2F89B: 0x38E3,  // 㣣 -> 㣣
+	0x2F89C: 0x5F9A,  // 徚 -> 徚
+	0x2F89D: 0x5FCD,  // 忍 -> 忍
+	0x2F89E: 0x5FD7,  // 志 -> 志
+	0x2F89F: 0x5FF9,  // 忹 -> 忹
+	0x2F8A0: 0x6081,  // 悁 -> 悁
+	0x2F8A1: 0x393A,  // 㤺 -> 㤺
+	0x2F8A2: 0x391C,  // 㤜 -> 㤜
+	0x2F8A3: 0x6094,  // 悔 -> 悔
+	0x2F8A4: 0x226D4, // 𢛔 -> 𢛔
+	0x2F8A5: 0x60C7,  // 惇 -> 惇
+	0x2F8A6: 0x6148,  // 慈 -> 慈
+	0x2F8A7: 0x614C,  // 慌 -> 慌
+	0x2F8A8: 0x614E,  // 慎 -> 慎
+	0x2F8A9: 0x614C,  // 慌 -> 慌
+	0x2F8AA: 0x617A,  // 慺 -> 慺
+	0x2F8AB: 0x618E,  // 憎 -> 憎
+	0x2F8AC: 0x61B2,  // 憲 -> 憲
+	0x2F8AD: 0x61A4,  // 憤 -> 憤
+	0x2F8AE: 0x61AF,  // 憯 -> 憯
+	0x2F8AF: 0x61DE,  // 懞 -> 懞
+	0x2F8B0: 0x61F2,  // 懲 -> 懲
+	0x2F8B1: 0x61F6,  // 懶 -> 懶
+	0x2F8B2: 0x6210,  // 成 -> 成
+	0x2F8B3: 0x621B,  // 戛 -> 戛
+	0x2F8B4: 0x625D,  // 扝 -> 扝
+	0x2F8B5: 0x62B1,  // 抱 -> 抱
+	0x2F8B6: 0x62D4,  // 拔 -> 拔
+	0x2F8B7: 0x6350,  // 捐 -> 捐
+	0x2F8B8: 0x22B0C, // 𢬌 -> 𢬌
+	0x2F8B9: 0x633D,  // 挽 -> 挽
+	0x2F8BA: 0x62FC,  // 拼 -> 拼
+	0x2F8BB: 0x6368,  // 捨 -> 捨
+	0x2F8BC: 0x6383,  // 掃 -> 掃
+	0x2F8BD: 0x63E4,  // 揤 -> 揤
+	0x2F8BE: 0x22BF1, // 𢯱 -> 𢯱
+	0x2F8BF: 0x6422,  // 搢 -> 搢
+	0x2F8C0: 0x63C5,  // 揅 -> 揅
+	0x2F8C1: 0x63A9,  // 掩 -> 掩
+	0x2F8C2: 0x3A2E,  // 㨮 -> 㨮
+	0x2F8C3: 0x6469,  // 摩 -> 摩
+	0x2F8C4: 0x647E,  // 摾 -> 摾
+	0x2F8C5: 0x649D,  // 撝 -> 撝
+	0x2F8C6: 0x6477,  // 摷 -> 摷
+	0x2F8C7: 0x3A6C,  // 㩬 -> 㩬
+	0x2F8C8: 0x654F,  // 敏 -> 敏
+	0x2F8C9: 0x656C,  // 敬 -> 敬
+	0x2F8CA: 0x2300A, // 𣀊 -> 𣀊
+	0x2F8CB: 0x65E3,  // 旣 -> 旣
+	0x2F8CC: 0x66F8,  // 書 -> 書
+	0x2F8CD: 0x6649,  // 晉 -> 晉
+	0x2F8CE: 0x3B19,  // 㬙 -> 㬙
+	0x2F8CF: 0x6691,  // 暑 -> 暑
+	0x2F8D0: 0x3B08,  // 㬈 -> 㬈
+	0x2F8D1: 0x3AE4,  // 㫤 -> 㫤
+	0x2F8D2: 0x5192,  // 冒 -> 冒
+	0x2F8D3: 0x5195,  // 冕 -> 冕
+	0x2F8D4: 0x6700,  // 最 -> 最
+	0x2F8D5: 0x669C,  // 暜 -> 暜
+	0x2F8D6: 0x80AD,  // 肭 -> 肭
+	0x2F8D7: 0x43D9,  // 䏙 -> 䏙
+	0x2F8D8: 0x6717,  // 朗 -> 朗
+	0x2F8D9: 0x671B,  // 望 -> 望
+	0x2F8DA: 0x6721,  // 朡 -> 朡
+	0x2F8DB: 0x675E,  // 杞 -> 杞
+	0x2F8DC: 0x6753,  // 杓 -> 杓
+	0x2F8DD: 0x233C3, // 𣏃 -> 𣏃
+	0x2F8DE: 0x3B49,  // 㭉 -> 㭉
+	0x2F8DF: 0x67FA,  // 柺 -> 柺
+	0x2F8E0: 0x6785,  // 枅 -> 枅
+	0x2F8E1: 0x6852,  // 桒 -> 桒
+	0x2F8E2: 0x6885,  // 梅 -> 梅
+	0x2F8E3: 0x2346D, // 𣑭 -> 𣑭
+	0x2F8E4: 0x688E,  // 梎 -> 梎
+	0x2F8E5: 0x681F,  // 栟 -> 栟
+	0x2F8E6: 0x6914,  // 椔 -> 椔
+	0x2F8E7: 0x3B9D,  // 㮝 -> 㮝
+	0x2F8E8: 0x6942,  // 楂 -> 楂
+	0x2F8E9: 0x69A3,  // 榣 -> 榣
+	0x2F8EA: 0x69EA,  // 槪 -> 槪
+	0x2F8EB: 0x6AA8,  // 檨 -> 檨
+	0x2F8EC: 0x236A3, // 𣚣 -> 𣚣
+	0x2F8ED: 0x6ADB,  // 櫛 -> 櫛
+	0x2F8EE: 0x3C18,  // 㰘 -> 㰘
+	0x2F8EF: 0x6B21,  // 次 -> 次
+	0x2F8F0: 0x238A7, // 𣢧 -> 𣢧
+	0x2F8F1: 0x6B54,  // 歔 -> 歔
+	0x2F8F2: 0x3C4E,  // 㱎 -> 㱎
+	0x2F8F3: 0x6B72,  // 歲 -> 歲
+	0x2F8F4: 0x6B9F,  // 殟 -> 殟
+	0x2F8F5: 0x6BBA,  // 殺 -> 殺
+	0x2F8F6: 0x6BBB,  // 殻 -> 殻
+	0x2F8F7: 0x23A8D, // 𣪍 -> 𣪍
+	0x2F8F8: 0x21D0B, // 𡴋 -> 𡴋
+	0x2F8F9: 0x23AFA, // 𣫺 -> 𣫺
+	0x2F8FA: 0x6C4E,  // 汎 -> 汎
+	0x2F8FB: 0x23CBC, // 𣲼 -> 𣲼
+	0x2F8FC: 0x6CBF,  // 沿 -> 沿
+	0x2F8FD: 0x6CCD,  // 泍 -> 泍
+	0x2F8FE: 0x6C67,  // 汧 -> 汧
+	0x2F8FF: 0x6D16,  // 洖 -> 洖
+	0x2F900: 0x6D3E,  // 派 -> 派
+	0x2F901: 0x6D77,  // 海 -> 海
+	0x2F902: 0x6D41,  // 流 -> 流
+	0x2F903: 0x6D69,  // 浩 -> 浩
+	0x2F904: 0x6D78,  // 浸 -> 浸
+	0x2F905: 0x6D85,  // 涅 -> 涅
+	0x2F906: 0x23D1E, // 𣴞 -> 𣴞
+	0x2F907: 0x6D34,  // 洴 -> 洴
+	0x2F908: 0x6E2F,  // 港 -> 港
+	0x2F909: 0x6E6E,  // 湮 -> 湮
+	0x2F90A: 0x3D33,  // 㴳 -> 㴳
+	0x2F90B: 0x6ECB,  // 滋 -> 滋
+	0x2F90C: 0x6EC7,  // 滇 -> 滇
+	0x2F90D: 0x23ED1, // 𣻑 -> 𣻑
+	0x2F90E: 0x6DF9,  // 淹 -> 淹
+	0x2F90F: 0x6F6E,  // 潮 -> 潮
+	0x2F910: 0x23F5E, // 𣽞 -> 𣽞
+	0x2F911: 0x23F8E, // 𣾎 -> 𣾎
+	0x2F912: 0x6FC6,  // 濆 -> 濆
+	0x2F913: 0x7039,  // 瀹 -> 瀹
+	0x2F914: 0x701E,  // 瀞 -> 瀞
+	0x2F915: 0x701B,  // 瀛 -> 瀛
+	0x2F916: 0x3D96,  // 㶖 -> 㶖
+	0x2F917: 0x704A,  // 灊 -> 灊
+	0x2F918: 0x707D,  // 災 -> 災
+	0x2F919: 0x7077,  // 灷 -> 灷
+	0x2F91A: 0x70AD,  // 炭 -> 炭
+	0x2F91B: 0x20525, // 𠔥 -> 𠔥
+	0x2F91C: 0x7145,  // 煅 -> 煅
+	0x2F91D: 0x24263, // 𤉣 -> 𤉣
+	0x2F91E: 0x719C,  // 熜 -> 熜
+	0x2F91F: 0x243AB, // 𤎫 -> 𤎫
+	0x2F920: 0x7228,  // 爨 -> 爨
+	0x2F921: 0x7235,  // 爵 -> 爵
+	0x2F922: 0x7250,  // 牐 -> 牐
+	0x2F923: 0x24608, // 𤘈 -> 𤘈
+	0x2F924: 0x7280,  // 犀 -> 犀
+	0x2F925: 0x7295,  // 犕 -> 犕
+	0x2F926: 0x24735, // 𤜵 -> 𤜵
+	0x2F927: 0x24814, // 𤠔 -> 𤠔
+	0x2F928: 0x737A,  // 獺 -> 獺
+	0x2F929: 0x738B,  // 王 -> 王
+	0x2F92A: 0x3EAC,  // 㺬 -> 㺬
+	0x2F92B: 0x73A5,  // 玥 -> 玥
+	0x2F92C: 0x3EB8,  // 㺸 -> 㺸
+	0x2F92D: 0x3EB8,  // 㺸 -> 㺸
+	0x2F92E: 0x7447,  // 瑇 -> 瑇
+	0x2F92F: 0x745C,  // 瑜 -> 瑜
+	0x2F930: 0x7471,  // 瑱 -> 瑱
+	0x2F931: 0x7485,  // 璅 -> 璅
+	0x2F932: 0x74CA,  // 瓊 -> 瓊
+	0x2F933: 0x3F1B,  // 㼛 -> 㼛
+	0x2F934: 0x7524,  // 甤 -> 甤
+	0x2F935: 0x24C36, // 𤰶 -> 𤰶
+	0x2F936: 0x753E,  // 甾 -> 甾
+	0x2F937: 0x24C92, // 𤲒 -> 𤲒
+	0x2F938: 0x7570,  // 異 -> 異
+	0x2F939: 0x2219F, // 𢆟 -> 𢆟
+	0x2F93A: 0x7610,  // 瘐 -> 瘐
+	0x2F93B: 0x24FA1, // 𤾡 -> 𤾡
+	0x2F93C: 0x24FB8, // 𤾸 -> 𤾸
+	0x2F93D: 0x25044, // 𥁄 -> 𥁄
+	0x2F93E: 0x3FFC,  // 㿼 -> 㿼
+	0x2F93F: 0x4008,  // 䀈 -> 䀈
+	0x2F940: 0x76F4,  // 直 -> 直
+	0x2F941: 0x250F3, // 𥃳 -> 𥃳
+	0x2F942: 0x250F2, // 𥃲 -> 𥃲
+	0x2F943: 0x25119, // 𥄙 -> 𥄙
+	0x2F944: 0x25133, // 𥄳 -> 𥄳
+	0x2F945: 0x771E,  // 眞 -> 眞
+	0x2F946: 0x771F,  // 真 -> 真
+	0x2F947: 0x771F,  // 真 -> 真
+	0x2F948: 0x774A,  // 睊 -> 睊
+	0x2F949: 0x4039,  // 䀹 -> 䀹
+	0x2F94A: 0x778B,  // 瞋 -> 瞋
+	0x2F94B: 0x4046,  // 䁆 -> 䁆
+	0x2F94C: 0x4096,  // 䂖 -> 䂖
+	0x2F94D: 0x2541D, // 𥐝 -> 𥐝
+	0x2F94E: 0x784E,  // 硎 -> 硎
+	0x2F94F: 0x788C,  // 碌 -> 碌
+	0x2F950: 0x78CC,  // 磌 -> 磌
+	0x2F951: 0x40E3,  // 䃣 -> 䃣
+	0x2F952: 0x25626, // 𥘦 -> 𥘦
+	0x2F953: 0x7956,  // 祖 -> 祖
+	0x2F954: 0x2569A, // 𥚚 -> 𥚚
+	0x2F955: 0x256C5, // 𥛅 -> 𥛅
+	0x2F956: 0x798F,  // 福 -> 福
+	0x2F957: 0x79EB,  // 秫 -> 秫
+	0x2F958: 0x412F,  // 䄯 -> 䄯
+	0x2F959: 0x7A40,  // 穀 -> 穀
+	0x2F95A: 0x7A4A,  // 穊 -> 穊
+	0x2F95B: 0x7A4F,  // 穏 -> 穏
+	0x2F95C: 0x2597C, // 𥥼 -> 𥥼
+	0x2F95D: 0x25AA7, // 𥪧 -> 𥪧
+	0x2F95E: 0x25AA7, // 𥪧 -> 𥪧
+	0x2F95F: 0x7AEE,  // 竮 -> 竮
+	0x2F960: 0x4202,  // 䈂 -> 䈂
+	0x2F961: 0x25BAB, // 𥮫 -> 𥮫
+	0x2F962: 0x7BC6,  // 篆 -> 篆
+	0x2F963: 0x7BC9,  // 築 -> 築
+	0x2F964: 0x4227,  // 䈧 -> 䈧
+	0x2F965: 0x25C80, // 𥲀 -> 𥲀
+	0x2F966: 0x7CD2,  // 糒 -> 糒
+	0x2F967: 0x42A0,  // 䊠 -> 䊠
+	0x2F968: 0x7CE8,  // 糨 -> 糨
+	0x2F969: 0x7CE3,  // 糣 -> 糣
+	0x2F96A: 0x7D00,  // 紀 -> 紀
+	0x2F96B: 0x25F86, // 𥾆 -> 𥾆
+	0x2F96C: 0x7D63,  // 絣 -> 絣
+	0x2F96D: 0x4301,  // 䌁 -> 䌁
+	0x2F96E: 0x7DC7,  // 緇 -> 緇
+	0x2F96F: 0x7E02,  // 縂 -> 縂
+	0x2F970: 0x7E45,  // 繅 -> 繅
+	0x2F971: 0x4334,  // 䌴 -> 䌴
+	0x2F972: 0x26228, // 𦈨 -> 𦈨
+	0x2F973: 0x26247, // 𦉇 -> 𦉇
+	0x2F974: 0x4359,  // 䍙 -> 䍙
+	0x2F975: 0x262D9, // 𦋙 -> 𦋙
+	0x2F976: 0x7F7A,  // 罺 -> 罺
+	0x2F977: 0x2633E, // 𦌾 -> 𦌾
+	0x2F978: 0x7F95,  // 羕 -> 羕
+	0x2F979: 0x7FFA,  // 翺 -> 翺
+	0x2F97A: 0x8005,  // 者 -> 者
+	0x2F97B: 0x264DA, // 𦓚 -> 𦓚
+	0x2F97C: 0x26523, // 𦔣 -> 𦔣
+	0x2F97D: 0x8060,  // 聠 -> 聠
+	0x2F97E: 0x265A8, // 𦖨 -> 𦖨
+	0x2F97F: 0x8070,  // 聰 -> 聰
+	0x2F980: 0x2335F, // 𣍟 -> 𣍟
+	0x2F981: 0x43D5,  // 䏕 -> 䏕
+	0x2F982: 0x80B2,  // 育 -> 育
+	0x2F983: 0x8103,  // 脃 -> 脃
+	0x2F984: 0x440B,  // 䐋 -> 䐋
+	0x2F985: 0x813E,  // 脾 -> 脾
+	0x2F986: 0x5AB5,  // 媵 -> 媵
+	0x2F987: 0x267A7, // 𦞧 -> 𦞧
+	0x2F988: 0x267B5, // 𦞵 -> 𦞵
+	0x2F989: 0x23393, // 𣎓 -> 𣎓
+	0x2F98A: 0x2339C, // 𣎜 -> 𣎜
+	0x2F98B: 0x8201,  // 舁 -> 舁
+	0x2F98C: 0x8204,  // 舄 -> 舄
+	0x2F98D: 0x8F9E,  // 辞 -> 辞
+	0x2F98E: 0x446B,  // 䑫 -> 䑫
+	0x2F98F: 0x8291,  // 芑 -> 芑
+	0x2F990: 0x828B,  // 芋 -> 芋
+	0x2F991: 0x829D,  // 芝 -> 芝
+	0x2F992: 0x52B3,  // 劳 -> 劳
+	0x2F993: 0x82B1,  // 花 -> 花
+	0x2F994: 0x82B3,  // 芳 -> 芳
+	0x2F995: 0x82BD,  // 芽 -> 芽
+	0x2F996: 0x82E6,  // 苦 -> 苦
+	0x2F997: 0x26B3C, // 𦬼 -> 𦬼
+	0x2F998: 0x82E5,  // 若 -> 若
+	0x2F999: 0x831D,  // 茝 -> 茝
+	0x2F99A: 0x8363,  // 荣 -> 荣
+	0x2F99B: 0x83AD,  // 莭 -> 莭
+	0x2F99C: 0x8323,  // 茣 -> 茣
+	0x2F99D: 0x83BD,  // 莽 -> 莽
+	0x2F99E: 0x83E7,  // 菧 -> 菧
+	0x2F99F: 0x8457,  // 著 -> 著
+	0x2F9A0: 0x8353,  // 荓 -> 荓
+	0x2F9A1: 0x83CA,  // 菊 -> 菊
+	0x2F9A2: 0x83CC,  // 菌 -> 菌
+	0x2F9A3: 0x83DC,  // 菜 -> 菜
+	0x2F9A4: 0x26C36, // 𦰶 -> 𦰶
+	0x2F9A5: 0x26D6B, // 𦵫 -> 𦵫
+	0x2F9A6: 0x26CD5, // 𦳕 -> 𦳕
+	0x2F9A7: 0x452B,  // 䔫 -> 䔫
+	0x2F9A8: 0x84F1,  // 蓱 -> 蓱
+	0x2F9A9: 0x84F3,  // 蓳 -> 蓳
+	0x2F9AA: 0x8516,  // 蔖 -> 蔖
+	0x2F9AB: 0x273CA, // 𧏊 -> 𧏊
+	0x2F9AC: 0x8564,  // 蕤 -> 蕤
+	0x2F9AD: 0x26F2C, // 𦼬 -> 𦼬
+	0x2F9AE: 0x455D,  // 䕝 -> 䕝
+	0x2F9AF: 0x4561,  // 䕡 -> 䕡
+	0x2F9B0: 0x26FB1, // 𦾱 -> 𦾱
+	0x2F9B1: 0x270D2, // 𧃒 -> 𧃒
+	0x2F9B2: 0x456B,  // 䕫 -> 䕫
+	0x2F9B3: 0x8650,  // 虐 -> 虐
+	0x2F9B4: 0x865C,  // 虜 -> 虜
+	0x2F9B5: 0x8667,  // 虧 -> 虧
+	0x2F9B6: 0x8669,  // 虩 -> 虩
+	0x2F9B7: 0x86A9,  // 蚩 -> 蚩
+	0x2F9B8: 0x8688,  // 蚈 -> 蚈
+	0x2F9B9: 0x870E,  // 蜎 -> 蜎
+	0x2F9BA: 0x86E2,  // 蛢 -> 蛢
+	0x2F9BB: 0x8779,  // 蝹 -> 蝹
+	0x2F9BC: 0x8728,  // 蜨 -> 蜨
+	0x2F9BD: 0x876B,  // 蝫 -> 蝫
+	0x2F9BE: 0x8786,  // 螆 -> 螆
+	0x2F9BF: 0x45D7,  // 䗗 -> 䗗
+	0x2F9C0: 0x87E1,  // 蟡 -> 蟡
+	0x2F9C1: 0x8801,  // 蠁 -> 蠁
+	0x2F9C2: 0x45F9,  // 䗹 -> 䗹
+	0x2F9C3: 0x8860,  // 衠 -> 衠
+	0x2F9C4: 0x8863,  // 衣 -> 衣
+	0x2F9C5: 0x27667, // 𧙧 -> 𧙧
+	0x2F9C6: 0x88D7,  // 裗 -> 裗
+	0x2F9C7: 0x88DE,  // 裞 -> 裞
+	0x2F9C8: 0x4635,  // 䘵 -> 䘵
+	0x2F9C9: 0x88FA,  // 裺 -> 裺
+	0x2F9CA: 0x34BB,  // 㒻 -> 㒻
+	0x2F9CB: 0x278AE, // 𧢮 -> 𧢮
+	0x2F9CC: 0x27966, // 𧥦 -> 𧥦
+	0x2F9CD: 0x46BE,  // 䚾 -> 䚾
+	0x2F9CE: 0x46C7,  // 䛇 -> 䛇
+	0x2F9CF: 0x8AA0,  // 誠 -> 誠
+	0x2F9D0: 0x8AED,  // 諭 -> 諭
+	0x2F9D1: 0x8B8A,  // 變 -> 變
+	0x2F9D2: 0x8C55,  // 豕 -> 豕
+	0x2F9D3: 0x27CA8, // 𧲨 -> 𧲨
+	0x2F9D4: 0x8CAB,  // 貫 -> 貫
+	0x2F9D5: 0x8CC1,  // 賁 -> 賁
+	0x2F9D6: 0x8D1B,  // 贛 -> 贛
+	0x2F9D7: 0x8D77,  // 起 -> 起
+	0x2F9D8: 0x27F2F, // 𧼯 -> 𧼯
+	0x2F9D9: 0x20804, // 𠠄 -> 𠠄
+	0x2F9DA: 0x8DCB,  // 跋 -> 跋
+	0x2F9DB: 0x8DBC,  // 趼 -> 趼
+	0x2F9DC: 0x8DF0,  // 跰 -> 跰
+	0x2F9DD: 0x208DE, // 𠣞 -> 𠣞
+	0x2F9DE: 0x8ED4,  // 軔 -> 軔
+	0x2F9DF: 0x8F38,  // 輸 -> 輸
+	0x2F9E0: 0x285D2, // 𨗒 -> 𨗒
+	0x2F9E1: 0x285ED, // 𨗭 -> 𨗭
+	0x2F9E2: 0x9094,  // 邔 -> 邔
+	0x2F9E3: 0x90F1,  // 郱 -> 郱
+	0x2F9E4: 0x9111,  // 鄑 -> 鄑
+	0x2F9E5: 0x2872E, // 𨜮 -> 𨜮
+	0x2F9E6: 0x911B,  // 鄛 -> 鄛
+	0x2F9E7: 0x9238,  // 鈸 -> 鈸
+	0x2F9E8: 0x92D7,  // 鋗 -> 鋗
+	0x2F9E9: 0x92D8,  // 鋘 -> 鋘
+	0x2F9EA: 0x927C,  // 鉼 -> 鉼
+	0x2F9EB: 0x93F9,  // 鏹 -> 鏹
+	0x2F9EC: 0x9415,  // 鐕 -> 鐕
+	0x2F9ED: 0x28BFA, // 𨯺 -> 𨯺
+	0x2F9EE: 0x958B,  // 開 -> 開
+	0x2F9EF: 0x4995,  // 䦕 -> 䦕
+	0x2F9F0: 0x95B7,  // 閷 -> 閷
+	0x2F9F1: 0x28D77, // 𨵷 -> 𨵷
+	0x2F9F2: 0x49E6,  // 䧦 -> 䧦
+	0x2F9F3: 0x96C3,  // 雃 -> 雃
+	0x2F9F4: 0x5DB2,  // 嶲 -> 嶲
+	0x2F9F5: 0x9723,  // 霣 -> 霣
+	0x2F9F6: 0x29145, // 𩅅 -> 𩅅
+	0x2F9F7: 0x2921A, // 𩈚 -> 𩈚
+	0x2F9F8: 0x4A6E,  // 䩮 -> 䩮
+	0x2F9F9: 0x4A76,  // 䩶 -> 䩶
+	0x2F9FA: 0x97E0,  // 韠 -> 韠
+	0x2F9FB: 0x2940A, // 𩐊 -> 𩐊
+	0x2F9FC: 0x4AB2,  // 䪲 -> 䪲
+	0x2F9FD: 0x29496, // 𩒖 -> 𩒖
+	0x2F9FE: 0x980B,  // 頋 -> 頋
+	0x2F9FF: 0x980B,  // 頋 -> 頋
+	0x2FA00: 0x9829,  // 頩 -> 頩
+	0x2FA01: 0x295B6, // 𩖶 -> 𩖶
+	0x2FA02: 0x98E2,  // 飢 -> 飢
+	0x2FA03: 0x4B33,  // 䬳 -> 䬳
+	0x2FA04: 0x9929,  // 餩 -> 餩
+	0x2FA05: 0x99A7,  // 馧 -> 馧
+	0x2FA06: 0x99C2,  // 駂 -> 駂
+	0x2FA07: 0x99FE,  // 駾 -> 駾
+	0x2FA08: 0x4BCE,  // 䯎 -> 䯎
+	0x2FA09: 0x29B30, // 𩬰 -> 𩬰
+	0x2FA0A: 0x9B12,  // 鬒 -> 鬒
+	0x2FA0B: 0x9C40,  // 鱀 -> 鱀
+	0x2FA0C: 0x9CFD,  // 鳽 -> 鳽
+	0x2FA0D: 0x4CCE,  // 䳎 -> 䳎
+	0x2FA0E: 0x4CED,  // 䳭 -> 䳭
+	0x2FA0F: 0x9D67,  // 鵧 -> 鵧
+	0x2FA10: 0x2A0CE, // 𪃎 -> 𪃎
+	0x2FA11: 0x4CF8,  // 䳸 -> 䳸
+	0x2FA12: 0x2A105, // 𪄅 -> 𪄅
+	0x2FA13: 0x2A20E, // 𪈎 -> 𪈎
+	0x2FA14: 0x2A291, // 𪊑 -> 𪊑
+	0x2FA15: 0x9EBB,  // 麻 -> 麻
+	0x2FA16: 0x4D56,  // 䵖 -> 䵖
+	0x2FA17: 0x9EF9,  // 黹 -> 黹
+	0x2FA18: 0x9EFE,  // 黾 -> 黾
+	0x2FA19: 0x9F05,  // 鼅 -> 鼅
+	0x2FA1A: 0x9F0F,  // 鼏 -> 鼏
+	0x2FA1B: 0x9F16,  // 鼖 -> 鼖
+	0x2FA1C: 0x9F3B,  // 鼻 -> 鼻
+	0x2FA1D: 0x2A600, // 𪘀 -> 𪘀
+}
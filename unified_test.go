@@ -0,0 +1,31 @@
+package pinyin
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestUnified(t *testing.T) {
+	for k, v := range UnifiedDict {
+		if !unicode.Is(unicode.Han, rune(v)) || k == v {
+			t.Errorf("%c (%X) maps to the non-unified %c", k, k, v)
+		}
+	}
+
+	a := NewPinyin(Tone3, Normal, " ", false, false)
+	for _, tc := range [][2]string{
+		{"⽇本", "rì běn "},   // Kangxi 日
+		{"\uF9B4", "lǐng "}, // compatibility ideograph 領
+		{"⻩河", "huáng hé "}, // simplified yellow radical
+		{"⺀", "⺀"},          // no unified ideograph
+	} {
+		if v := a.Convert(tc[0]); v != tc[1] {
+			t.Errorf("'%s' expects '%s', got '%s'", tc[0], tc[1], v)
+		}
+	}
+
+	tokens := a.Tokens("⽇本")
+	if len(tokens) != 2 || tokens[0].Unified != "日" || tokens[1].Unified != "" {
+		t.Errorf("Expected the unified 日 to be reported, got %+v", tokens)
+	}
+}